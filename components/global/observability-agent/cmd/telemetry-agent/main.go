@@ -22,13 +22,25 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"sync"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/adapter"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/aggregator"
+	als_receiver "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/als-receiver"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/config"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/processor"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/publisher"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+	scrape_receiver "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/scrape-receiver"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/signals"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store/database"
@@ -40,8 +52,27 @@ import (
 const (
 	configFilePathEnv     string = "CONFIG_FILE_PATH"
 	defaultConfigFilePath string = "/etc/conf/config.json"
+	logEntriesDirectory   string = "logentries"
+	logEntriesTable       string = "log_entries"
+	traceSpansDirectory   string = "tracespans"
+	traceSpansTable       string = "trace_spans"
+	pipelinesDirectory    string = "pipelines"
+	pipelineTablePrefix   string = "pipeline_"
 )
 
+// pipelineNamePattern restricts the names of the pipelines, which are used in the file paths and the table names
+var pipelineNamePattern = regexp.MustCompile("^[a-zA-Z0-9_]+$")
+
+// recordPipeline publishes the records of a Mixer template other than metric, or the records routed to a named
+// pipeline by the handlers, to an SP stream of its own
+type recordPipeline struct {
+	name      string
+	endpoint  *publisher.SpEndpoint
+	buffer    chan string
+	directory string
+	table     string
+}
+
 func main() {
 	port := adapter.AdapterPort
 	// Initialize a channel to handle interruptions from external sources like Kubernetes. This channel will handle
@@ -78,16 +109,92 @@ func main() {
 	bufferTimeoutSeconds := advancedConfig.BufferTimeoutSeconds
 	maxMetricsCount := advancedConfig.MaxRecordsForSingleWrite
 	bufferSizeFactor := advancedConfig.BufferSizeFactor
-	tickerSec := configuration.SpEndpoint.SendIntervalSeconds
+	sendIntervalSec := configuration.SpEndpoint.SendIntervalSeconds
 
 	client := &http.Client{}
 	buffer := make(chan string, maxMetricsCount*bufferSizeFactor)
+	// Notifies the publisher whenever the writer persists a new batch
+	notifyCh := make(chan struct{}, 1)
 	errCh := make(chan error, 1)
-	spAdapter, err := adapter.New(port, logger, client, buffer, &configuration.Mixer)
+	recordPipelines := []recordPipeline{
+		{
+			name:      "logentry",
+			endpoint:  configuration.LogEntrySpEndpoint,
+			directory: logEntriesDirectory,
+			table:     logEntriesTable,
+		},
+		{
+			name:      "tracespan",
+			endpoint:  configuration.TraceSpanSpEndpoint,
+			directory: traceSpansDirectory,
+			table:     traceSpansTable,
+		},
+	}
+	pipelineNames := make([]string, 0, len(configuration.Pipelines))
+	for name := range configuration.Pipelines {
+		if !pipelineNamePattern.MatchString(name) {
+			logger.Fatalf("Invalid pipeline name %s, which should contain only letters, digits and underscores", name)
+		}
+		pipelineNames = append(pipelineNames, name)
+	}
+	sort.Strings(pipelineNames)
+	for _, name := range pipelineNames {
+		recordPipelines = append(recordPipelines, recordPipeline{
+			name:      name,
+			endpoint:  configuration.Pipelines[name],
+			directory: filepath.Join(pipelinesDirectory, name),
+			table:     pipelineTablePrefix + name,
+		})
+	}
+	overflowHandler, err := overflow.New(advancedConfig.Overflow, logger)
+	if err != nil {
+		logger.Fatalf("Could not create the overflow handler : %v", err)
+	}
+	redactor, err := redaction.New(configuration.Redaction)
+	if err != nil {
+		logger.Fatalf("Could not create the redactor : %v", err)
+	}
+	// The records of the requests are written to the buffer through the aggregator if the aggregation is enabled
+	requestBuffer := buffer
+	var recordAggregator *aggregator.Aggregator
+	if configuration.Aggregation != nil {
+		logger.Info("Enabling the aggregation")
+		requestBuffer = make(chan string, maxMetricsCount*bufferSizeFactor)
+		recordAggregator, err = aggregator.New(configuration.Aggregation, logger, requestBuffer, buffer)
+		if err != nil {
+			logger.Fatalf("Could not create the aggregator : %v", err)
+		}
+	}
+	buffers := &adapter.Buffers{
+		Metric:    requestBuffer,
+		Pipelines: make(map[string]chan string, len(pipelineNames)),
+		Overflow:  overflowHandler,
+		Redactor:  redactor,
+	}
+	for i := range recordPipelines {
+		if recordPipelines[i].endpoint != nil {
+			recordPipelines[i].buffer = make(chan string, maxMetricsCount*bufferSizeFactor)
+		}
+	}
+	buffers.LogEntry = recordPipelines[0].buffer
+	buffers.TraceSpan = recordPipelines[1].buffer
+	for _, pipeline := range recordPipelines[2:] {
+		buffers.Pipelines[pipeline.name] = pipeline.buffer
+	}
+	spAdapter, err := adapter.New(port, logger, client, buffers, &configuration.Mixer)
 	if err != nil {
 		logger.Fatalf("unable to start the server: %v", err)
 	}
 	go spAdapter.Run(errCh)
+	if configuration.AccessLogService != nil {
+		// The access logs of Envoy are written to the same buffer as the metrics received from Mixer
+		alsReceiver, err := als_receiver.New(configuration.AccessLogService, logger, requestBuffer,
+			overflowHandler, redactor)
+		if err != nil {
+			logger.Fatalf("unable to start the access log service: %v", err)
+		}
+		go alsReceiver.Run(errCh)
+	}
 
 	var ps store.Persister
 	metricsStore := configuration.Store
@@ -119,6 +226,60 @@ func main() {
 		}
 	}
 
+	wireCodec, err := codec.New(configuration.SpEndpoint.Encoding, codec.TelemetrySchema)
+	if err != nil {
+		logger.Fatalf("Could not get the codec for the SP endpoint : %v", err)
+	}
+	compressor, err := publisher.NewCompressor(configuration.SpEndpoint.Compression,
+		configuration.SpEndpoint.CompressionLevel)
+	if err != nil {
+		logger.Fatalf("Could not get the compressor for the SP endpoint : %v", err)
+	}
+	var exporters []publisher.Exporter
+	if configuration.Exporters.Otlp != nil {
+		logger.Info("Enabling the OTLP exporter")
+		exporters = append(exporters, publisher.NewOtlpExporter(configuration.Exporters.Otlp, codec.TelemetrySchema,
+			&http.Client{}, logger))
+	}
+	if configuration.Exporters.PrometheusRemoteWrite != nil {
+		logger.Info("Enabling the Prometheus remote-write exporter")
+		exporters = append(exporters, publisher.NewPrometheusExporter(configuration.Exporters.PrometheusRemoteWrite,
+			&http.Client{}, logger))
+	}
+	if configuration.Exporters.Elasticsearch != nil {
+		logger.Info("Enabling the Elasticsearch exporter")
+		exporters = append(exporters, publisher.NewElasticsearchExporter(configuration.Exporters.Elasticsearch,
+			codec.TelemetrySchema, &http.Client{}, logger))
+	}
+	var kafkaExporter *publisher.KafkaExporter
+	if configuration.Exporters.Kafka != nil {
+		logger.Info("Enabling the Kafka exporter")
+		kafkaExporter, err = publisher.NewKafkaExporter(configuration.Exporters.Kafka, codec.TelemetrySchema, logger)
+		if err != nil {
+			logger.Fatalf("Could not get the Kafka exporter : %v", err)
+		}
+		exporters = append(exporters, kafkaExporter)
+	}
+	var debugExporter *publisher.DebugExporter
+	if configuration.Exporters.Debug != nil || configuration.DryRun {
+		debugConfig := configuration.Exporters.Debug
+		if debugConfig == nil {
+			debugConfig = &publisher.Debug{Pretty: true}
+		}
+		debugExporter, err = publisher.NewDebugExporter(debugConfig)
+		if err != nil {
+			logger.Fatalf("Could not get the debug exporter : %v", err)
+		}
+		exporters = append(exporters, debugExporter)
+	}
+	spServerUrl := configuration.SpEndpoint.URL
+	if configuration.DryRun {
+		logger.Info("Running in dry run mode, hence the batches are written by the debug exporter instead of " +
+			"being published")
+		spServerUrl = ""
+		exporters = []publisher.Exporter{debugExporter}
+	}
+
 	var waitGroup sync.WaitGroup
 	wrt := &writer.Writer{
 		WaitingTimeSec:  bufferTimeoutSeconds,
@@ -127,25 +288,87 @@ func main() {
 		Buffer:          buffer,
 		LastWrittenTime: time.Now(),
 		Persister:       ps,
+		Notify:          notifyCh,
 	}
-	ticker := time.NewTicker(time.Duration(tickerSec) * time.Second)
 	pub := &publisher.Publisher{
-		Ticker:      ticker,
-		Logger:      logger,
-		SpServerUrl: configuration.SpEndpoint.URL,
-		HttpClient:  &http.Client{},
-		Persister:   ps,
+		SendInterval:        time.Duration(sendIntervalSec) * time.Second,
+		Notify:              notifyCh,
+		Logger:              logger,
+		SpServerUrl:         spServerUrl,
+		HttpClient:          &http.Client{},
+		Persister:           ps,
+		Exporters:           exporters,
+		MaxPayloadBytes:     configuration.SpEndpoint.MaxPayloadBytes,
+		Codec:               wireCodec,
+		Compressor:          compressor,
+		Workers:             configuration.SpEndpoint.Workers,
+		MaxInFlightRequests: configuration.SpEndpoint.MaxInFlightRequests,
+		PreserveOrder:       configuration.SpEndpoint.PreserveOrder,
+		RecordsPerSecond:    configuration.SpEndpoint.RecordsPerSecond,
+		BytesPerSecond:      configuration.SpEndpoint.BytesPerSecond,
+		SigningSecret:       configuration.SpEndpoint.SigningSecret,
+		SignatureHeader:     configuration.SpEndpoint.SignatureHeader,
+		Streaming:           configuration.SpEndpoint.Streaming,
+		MaxRequestBytes:     configuration.SpEndpoint.MaxRequestBytes,
 	}
-	go func() {
+	// The writer drains the buffer only after the aggregator has written the partial window to it
+	writerStopCh := stopCh
+	if recordAggregator != nil {
+		aggregatorDoneCh := make(chan struct{})
+		writerStopCh = aggregatorDoneCh
 		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			defer close(aggregatorDoneCh)
+			recordAggregator.Run(stopCh)
+		}()
+	}
+	waitGroup.Add(2)
+	go func() {
 		defer waitGroup.Done()
-		wrt.Run(stopCh)
+		wrt.Run(writerStopCh)
 	}()
 	go func() {
-		waitGroup.Add(1)
 		defer waitGroup.Done()
 		pub.Run(stopCh)
 	}()
+	if configuration.PrometheusScrape != nil {
+		// The scraped metrics are already aggregated by the proxies, hence they are not written through the aggregator
+		logger.Info("Enabling the Prometheus scrape receiver")
+		scrapeBuffer, err := configuration.PrometheusScrape.PipelineBuffer(buffers.Pipelines)
+		if err != nil {
+			logger.Fatalf("Could not enable the Prometheus scrape receiver : %v", err)
+		}
+		processors, err := processor.New(configuration.Mixer.Processors)
+		if err != nil {
+			logger.Fatalf("Could not create the attribute processors : %v", err)
+		}
+		scraper := scrape_receiver.New(configuration.PrometheusScrape, &http.Client{}, logger, scrapeBuffer,
+			overflowHandler, redactor, processors)
+		go scraper.Run(stopCh)
+	}
+	for _, pipeline := range recordPipelines {
+		if pipeline.buffer == nil {
+			continue
+		}
+		// Each pipeline has its own writer and publisher, so that the records are batched, persisted and retried
+		// independently of the metrics
+		logger.Infof("Enabling the %s pipeline", pipeline.name)
+		pipelineWrt, pipelinePub, err := newRecordPipeline(configuration, pipeline, kafkaExporter, debugExporter,
+			logger)
+		if err != nil {
+			logger.Fatalf("Could not create the %s pipeline : %v", pipeline.name, err)
+		}
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			pipelineWrt.Run(stopCh)
+		}()
+		go func() {
+			defer waitGroup.Done()
+			pipelinePub.Run(stopCh)
+		}()
+	}
 
 	select {
 	case <-stopCh:
@@ -153,9 +376,91 @@ func main() {
 		// If any interruption happens, this will give some time to clear in memory buffers by persisting them to
 		// prevent data losses.
 		waitGroup.Wait()
+		if kafkaExporter != nil {
+			if err = kafkaExporter.Close(); err != nil {
+				logger.Warnf("Could not close the Kafka producer : %v", err)
+			}
+		}
+		if dropped := overflowHandler.Dropped(); len(dropped) > 0 {
+			logger.Warnf("Records dropped since the buffers were full, by the reason : %v", dropped)
+		}
 	case err = <-errCh:
 		if err != nil {
 			logger.Fatalf("Something went wrong when initializing the adapter : %v", err)
 		}
 	}
 }
+
+// newRecordPipeline creates the writer and the publisher of a pipeline, whose records are persisted separately from
+// the metrics using a persister of the same kind. The records are also produced to the Kafka topic of the pipeline
+// if the Kafka exporter is enabled.
+func newRecordPipeline(configuration *config.Config, pipeline recordPipeline, kafkaExporter *publisher.KafkaExporter,
+	debugExporter *publisher.DebugExporter, logger *zap.SugaredLogger) (*writer.Writer, *publisher.Publisher, error) {
+	advancedConfig := configuration.Advanced
+	var ps store.Persister
+	var err error
+	if configuration.Store.File != nil {
+		ps, err = file.NewPersister(&file.File{
+			Path: filepath.Join(configuration.Store.File.Path, pipeline.directory),
+		}, logger)
+	} else if configuration.Store.Database != nil {
+		databaseConfig := *configuration.Store.Database
+		databaseConfig.Table = pipeline.table
+		ps, err = database.NewPersister(&databaseConfig, logger)
+	} else {
+		ps, err = memory.NewPersister(advancedConfig.MaxRecordsForSingleWrite, advancedConfig.BufferSizeFactor, logger)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := pipeline.endpoint
+	wireCodec, err := codec.New(endpoint.Encoding, codec.TelemetrySchema)
+	if err != nil {
+		return nil, nil, err
+	}
+	compressor, err := publisher.NewCompressor(endpoint.Compression, endpoint.CompressionLevel)
+	if err != nil {
+		return nil, nil, err
+	}
+	spServerUrl := endpoint.URL
+	var exporters []publisher.Exporter
+	if kafkaExporter != nil {
+		exporters = append(exporters, kafkaExporter.ForPipeline(pipeline.name))
+	}
+	if configuration.DryRun {
+		spServerUrl = ""
+		exporters = []publisher.Exporter{debugExporter}
+	}
+	notifyCh := make(chan struct{}, 1)
+	wrt := &writer.Writer{
+		WaitingTimeSec:  advancedConfig.BufferTimeoutSeconds,
+		WaitingSize:     advancedConfig.MaxRecordsForSingleWrite,
+		Logger:          logger,
+		Buffer:          pipeline.buffer,
+		LastWrittenTime: time.Now(),
+		Persister:       ps,
+		Notify:          notifyCh,
+	}
+	pub := &publisher.Publisher{
+		SendInterval:        time.Duration(endpoint.SendIntervalSeconds) * time.Second,
+		Notify:              notifyCh,
+		Logger:              logger,
+		SpServerUrl:         spServerUrl,
+		HttpClient:          &http.Client{},
+		Persister:           ps,
+		Exporters:           exporters,
+		MaxPayloadBytes:     endpoint.MaxPayloadBytes,
+		Codec:               wireCodec,
+		Compressor:          compressor,
+		Workers:             endpoint.Workers,
+		MaxInFlightRequests: endpoint.MaxInFlightRequests,
+		PreserveOrder:       endpoint.PreserveOrder,
+		RecordsPerSecond:    endpoint.RecordsPerSecond,
+		BytesPerSecond:      endpoint.BytesPerSecond,
+		SigningSecret:       endpoint.SigningSecret,
+		SignatureHeader:     endpoint.SignatureHeader,
+		Streaming:           endpoint.Streaming,
+		MaxRequestBytes:     endpoint.MaxRequestBytes,
+	}
+	return wrt, pub, nil
+}
@@ -22,11 +22,17 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/config"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/publisher"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/signals"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store/database"
@@ -41,6 +47,10 @@ import (
 const (
 	configFilePathEnv     string = "CONFIG_FILE_PATH"
 	defaultConfigFilePath string = "/etc/conf/config.json"
+	// The file persister only reads the files directly inside its directory, hence the forwarded spans can be kept
+	// in a sub directory
+	forwardedSpansDirectory string = "forwarded"
+	forwardedSpansTable     string = "forwarded_spans"
 )
 
 func main() {
@@ -76,11 +86,26 @@ func main() {
 	bufferTimeoutSeconds := advancedConfig.BufferTimeoutSeconds
 	maxMetricsCount := advancedConfig.MaxRecordsForSingleWrite
 	bufferSizeFactor := advancedConfig.BufferSizeFactor
-	tickerSec := configuration.SpEndpoint.SendIntervalSeconds
+	sendIntervalSec := configuration.SpEndpoint.SendIntervalSeconds
 
 	buffer := make(chan string, maxMetricsCount*bufferSizeFactor)
+	// Notifies the publisher whenever the writer persists a new batch
+	notifyCh := make(chan struct{}, 1)
 	errCh := make(chan error, 1)
-	tracingReceiver := tracing_receiver.New(logger, buffer)
+	// The received spans are also kept in a separate buffer when they need to be forwarded to a tracing backend
+	var forwardBuffer chan string
+	if configuration.Exporters.TracingBackend != nil {
+		forwardBuffer = make(chan string, maxMetricsCount*bufferSizeFactor)
+	}
+	overflowHandler, err := overflow.New(advancedConfig.Overflow, logger)
+	if err != nil {
+		logger.Fatalf("Could not create the overflow handler : %v", err)
+	}
+	redactor, err := redaction.New(configuration.Redaction)
+	if err != nil {
+		logger.Fatalf("Could not create the redactor : %v", err)
+	}
+	tracingReceiver := tracing_receiver.New(logger, buffer, forwardBuffer, overflowHandler, redactor)
 	go tracingReceiver.Run(errCh)
 
 	var ps store.Persister
@@ -113,6 +138,55 @@ func main() {
 		}
 	}
 
+	wireCodec, err := codec.New(configuration.SpEndpoint.Encoding, codec.SpanSchema)
+	if err != nil {
+		logger.Fatalf("Could not get the codec for the SP endpoint : %v", err)
+	}
+	compressor, err := publisher.NewCompressor(configuration.SpEndpoint.Compression,
+		configuration.SpEndpoint.CompressionLevel)
+	if err != nil {
+		logger.Fatalf("Could not get the compressor for the SP endpoint : %v", err)
+	}
+	var exporters []publisher.Exporter
+	if configuration.Exporters.Otlp != nil {
+		logger.Info("Enabling the OTLP exporter")
+		exporters = append(exporters, publisher.NewOtlpExporter(configuration.Exporters.Otlp, codec.SpanSchema,
+			&http.Client{}, logger))
+	}
+	if configuration.Exporters.Elasticsearch != nil {
+		logger.Info("Enabling the Elasticsearch exporter")
+		exporters = append(exporters, publisher.NewElasticsearchExporter(configuration.Exporters.Elasticsearch,
+			codec.SpanSchema, &http.Client{}, logger))
+	}
+	var kafkaExporter *publisher.KafkaExporter
+	if configuration.Exporters.Kafka != nil {
+		logger.Info("Enabling the Kafka exporter")
+		kafkaExporter, err = publisher.NewKafkaExporter(configuration.Exporters.Kafka, codec.SpanSchema, logger)
+		if err != nil {
+			logger.Fatalf("Could not get the Kafka exporter : %v", err)
+		}
+		exporters = append(exporters, kafkaExporter)
+	}
+	var debugExporter *publisher.DebugExporter
+	if configuration.Exporters.Debug != nil || configuration.DryRun {
+		debugConfig := configuration.Exporters.Debug
+		if debugConfig == nil {
+			debugConfig = &publisher.Debug{Pretty: true}
+		}
+		debugExporter, err = publisher.NewDebugExporter(debugConfig)
+		if err != nil {
+			logger.Fatalf("Could not get the debug exporter : %v", err)
+		}
+		exporters = append(exporters, debugExporter)
+	}
+	spServerUrl := configuration.SpEndpoint.URL
+	if configuration.DryRun {
+		logger.Info("Running in dry run mode, hence the batches are written by the debug exporter instead of " +
+			"being published")
+		spServerUrl = ""
+		exporters = []publisher.Exporter{debugExporter}
+	}
+
 	var waitGroup sync.WaitGroup
 	wrt := &writer.Writer{
 		WaitingTimeSec:  bufferTimeoutSeconds,
@@ -121,35 +195,119 @@ func main() {
 		Buffer:          buffer,
 		LastWrittenTime: time.Now(),
 		Persister:       ps,
+		Notify:          notifyCh,
 	}
-	ticker := time.NewTicker(time.Duration(tickerSec) * time.Second)
 	pub := &publisher.Publisher{
-		Ticker:      ticker,
-		Logger:      logger,
-		SpServerUrl: configuration.SpEndpoint.URL,
-		HttpClient:  &http.Client{},
-		Persister:   ps,
+		SendInterval:        time.Duration(sendIntervalSec) * time.Second,
+		Notify:              notifyCh,
+		Logger:              logger,
+		SpServerUrl:         spServerUrl,
+		HttpClient:          &http.Client{},
+		Persister:           ps,
+		Exporters:           exporters,
+		MaxPayloadBytes:     configuration.SpEndpoint.MaxPayloadBytes,
+		Codec:               wireCodec,
+		Compressor:          compressor,
+		Workers:             configuration.SpEndpoint.Workers,
+		MaxInFlightRequests: configuration.SpEndpoint.MaxInFlightRequests,
+		PreserveOrder:       configuration.SpEndpoint.PreserveOrder,
+		RecordsPerSecond:    configuration.SpEndpoint.RecordsPerSecond,
+		BytesPerSecond:      configuration.SpEndpoint.BytesPerSecond,
+		SigningSecret:       configuration.SpEndpoint.SigningSecret,
+		SignatureHeader:     configuration.SpEndpoint.SignatureHeader,
+		Streaming:           configuration.SpEndpoint.Streaming,
+		MaxRequestBytes:     configuration.SpEndpoint.MaxRequestBytes,
 	}
+	waitGroup.Add(2)
 	go func() {
-		waitGroup.Add(1)
 		defer waitGroup.Done()
 		wrt.Run(stopCh)
 	}()
 	go func() {
-		waitGroup.Add(1)
 		defer waitGroup.Done()
 		pub.Run(stopCh)
 	}()
 
+	if forwardBuffer != nil {
+		// The spans are forwarded by their own writer and publisher, so that a tracing backend which is down does
+		// not hold back the spans sent to SP and vice versa
+		forwardPersister, err := newForwardPersister(configuration, maxMetricsCount, bufferSizeFactor, logger)
+		if err != nil {
+			logger.Fatalf("Could not get the persister for forwarding the spans : %v", err)
+		}
+		forwarder, err := publisher.NewTracingBackendExporter(configuration.Exporters.TracingBackend,
+			&http.Client{}, logger)
+		if err != nil {
+			logger.Fatalf("Could not get the tracing backend exporter : %v", err)
+		}
+		logger.Infof("Enabling span forwarding to the %s tracing backend", forwarder.Name())
+		forwardExporters := []publisher.Exporter{forwarder}
+		if configuration.DryRun {
+			forwardExporters = []publisher.Exporter{debugExporter}
+		}
+		forwardNotifyCh := make(chan struct{}, 1)
+		forwardWrt := &writer.Writer{
+			WaitingTimeSec:  bufferTimeoutSeconds,
+			WaitingSize:     maxMetricsCount,
+			Logger:          logger,
+			Buffer:          forwardBuffer,
+			LastWrittenTime: time.Now(),
+			Persister:       forwardPersister,
+			Notify:          forwardNotifyCh,
+		}
+		forwardPub := &publisher.Publisher{
+			SendInterval: time.Duration(sendIntervalSec) * time.Second,
+			Notify:       forwardNotifyCh,
+			Logger:       logger,
+			Persister:    forwardPersister,
+			Exporters:    forwardExporters,
+		}
+		waitGroup.Add(2)
+		go func() {
+			defer waitGroup.Done()
+			forwardWrt.Run(stopCh)
+		}()
+		go func() {
+			defer waitGroup.Done()
+			forwardPub.Run(stopCh)
+		}()
+	}
+
 	select {
 	case <-stopCh:
 		// This will wait for publisher and writer
 		// If any interruption happens, this will give some time to clear in memory buffers by persisting them to
 		// prevent data losses.
 		waitGroup.Wait()
+		if kafkaExporter != nil {
+			if err = kafkaExporter.Close(); err != nil {
+				logger.Warnf("Could not close the Kafka producer : %v", err)
+			}
+		}
+		if dropped := overflowHandler.Dropped(); len(dropped) > 0 {
+			logger.Warnf("Records dropped since the buffers were full, by the reason : %v", dropped)
+		}
 	case err = <-errCh:
 		if err != nil {
 			logger.Fatalf("Something went wrong when initializing the tracing receiver : %v", err)
 		}
 	}
 }
+
+// newForwardPersister creates a persister of the same kind as the one used for SP, but which keeps the forwarded
+// spans separately
+func newForwardPersister(configuration *config.Config, maxMetricsCount int, bufferSizeFactor int,
+	logger *zap.SugaredLogger) (store.Persister, error) {
+	spansStore := configuration.Store
+	if spansStore.File != nil {
+		return file.NewPersister(&file.File{
+			Path: filepath.Join(spansStore.File.Path, forwardedSpansDirectory),
+		}, logger)
+	}
+	if spansStore.Database != nil {
+		databaseConfig := *spansStore.Database
+		databaseConfig.Table = forwardedSpansTable
+		return database.NewPersister(&databaseConfig, logger)
+	}
+	return memory.NewPersister(maxMetricsCount, bufferSizeFactor, logger)
+}
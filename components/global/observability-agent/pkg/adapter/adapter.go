@@ -18,8 +18,9 @@
 
 // nolint:lll
 // Generates the adapter's resource yaml. It contains the adapter's configuration, name,
-// supported template names (metric in this case), and whether it is session or no-session based.
-//go:generate $GOPATH/src/istio.io/istio/bin/mixer_codegen.sh -a mixer/adapter/adapter/config/config.proto -x "-s=false -n adapter -t metric"
+// supported template names (metric, logentry and tracespan in this case), and whether it is session or no-session based.
+//go:generate protoc -I. --gogoslick_out=plugins=grpc:. --include_imports --include_source_info --descriptor_set_out=config/config.proto_descriptor config/config.proto
+//go:generate go run istio.io/istio/mixer/tools/mixgen adapter -c config/config.proto_descriptor -o ../../test/e2e/testdata/sample-adapter.yaml -s=true -n wso2spadapter -t metric -t logentry -t tracespan
 
 package adapter
 
@@ -32,18 +33,31 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
-
-	"google.golang.org/grpc/credentials"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 	"istio.io/api/mixer/adapter/model/v1beta1"
 	policy "istio.io/api/policy/v1beta1"
+	"istio.io/istio/mixer/template/logentry"
 	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/tracespan"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/processor"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
 )
 
 const (
 	AdapterPort int = 9091
+
+	// InstanceNameKey and ValueKey are the keys of a record holding the name and the value of the metric instance
+	InstanceNameKey = "instanceName"
+	ValueKey        = "value"
 )
 
 type (
@@ -54,17 +68,44 @@ type (
 		Run(errCh chan error)
 	}
 
-	// The struct for the adapter. (Adapter supports metric template)
+	// The struct for the adapter. (Adapter supports metric, logentry and tracespan templates)
 	Adapter struct {
-		listener   net.Listener
-		server     *grpc.Server
-		logger     *zap.SugaredLogger
-		httpClient *http.Client
-		buffer     chan string
+		listener         net.Listener
+		server           *grpc.Server
+		logger           *zap.SugaredLogger
+		httpClient       *http.Client
+		buffer           chan string
+		logEntryBuffer   chan string
+		traceSpanBuffer  chan string
+		pipelines        map[string]chan string
+		overflow         *overflow.Handler
+		processors       *processor.Chain
+		redactor         *redaction.Redactor
+		sessionsLock     sync.RWMutex
+		sessions         map[string]*session
+		sessionsByConfig map[string]*session
+		lastSessionID    int64
+		sample           func() float64
+	}
+
+	// Buffers are the buffers to which the adapter writes the records
+	Buffers struct {
+		Metric chan string
+		// LogEntry and TraceSpan are the buffers of the logentry and tracespan templates. The templates are served
+		// only if either their buffers or pipelines are given.
+		LogEntry  chan string
+		TraceSpan chan string
+		// Pipelines are the buffers of the named pipelines, to which the handlers can route their records
+		Pipelines map[string]chan string
+		// Overflow and Redactor are applied to the records written to the buffers
+		Overflow *overflow.Handler
+		Redactor *redaction.Redactor
 	}
 
 	Mixer struct {
 		*TLS `json:"tls"`
+		// Processors are applied in order to the decoded records before they are written to the buffers
+		Processors []*processor.Processor `json:"processors"`
 	}
 	TLS struct {
 		Certificate   string `json:"certificate"`
@@ -75,23 +116,140 @@ type (
 
 // Decode received metrics from the mixer
 func (adapter *Adapter) HandleMetric(ctx context.Context, r *metric.HandleMetricRequest) (*v1beta1.ReportResult, error) {
-
-	var instances = r.Instances
-	for _, inst := range instances {
-		var attributesMap = decodeDimensions(inst.Dimensions)
+	session, err := adapter.sessionFor(r.AdapterConfig)
+	if err != nil {
+		return nil, err
+	}
+	var records []map[string]interface{}
+	for _, inst := range r.Instances {
+		if !session.sampled(adapter.random()) {
+			continue
+		}
+		var attributesMap = decodeInstance(inst, session)
 		adapter.logger.Debugf("received request : %s", attributesMap)
-		adapter.writeToBuffer(attributesMap)
+		records = append(records, attributesMap)
+	}
+	err = adapter.writeToBuffer(session.bufferOr(adapter.buffer), records)
+	if err != nil {
+		return nil, err
+	}
+	return &v1beta1.ReportResult{}, nil
+}
+
+// Decode received log entries from the mixer
+func (adapter *Adapter) HandleLogEntry(ctx context.Context, r *logentry.HandleLogEntryRequest) (*v1beta1.ReportResult, error) {
+	session, err := adapter.sessionFor(r.AdapterConfig)
+	if err != nil {
+		return nil, err
+	}
+	buffer := session.bufferOr(adapter.logEntryBuffer)
+	if buffer == nil {
+		return nil, fmt.Errorf("no pipeline is configured for the logentry template")
+	}
+	var records []map[string]interface{}
+	for _, inst := range r.Instances {
+		if !session.sampled(adapter.random()) {
+			continue
+		}
+		var attributesMap = decodeLogEntry(inst, session)
+		adapter.logger.Debugf("received log entry : %s", attributesMap)
+		records = append(records, attributesMap)
+	}
+	err = adapter.writeToBuffer(buffer, records)
+	if err != nil {
+		return nil, err
 	}
+	return &v1beta1.ReportResult{}, nil
+}
 
+// Decode received trace spans from the mixer
+func (adapter *Adapter) HandleTraceSpan(ctx context.Context, r *tracespan.HandleTraceSpanRequest) (*v1beta1.ReportResult, error) {
+	session, err := adapter.sessionFor(r.AdapterConfig)
+	if err != nil {
+		return nil, err
+	}
+	buffer := session.bufferOr(adapter.traceSpanBuffer)
+	if buffer == nil {
+		return nil, fmt.Errorf("no pipeline is configured for the tracespan template")
+	}
+	var records []map[string]interface{}
+	for _, inst := range r.Instances {
+		if !session.sampled(adapter.random()) {
+			continue
+		}
+		var attributesMap = decodeTraceSpan(inst, session)
+		adapter.logger.Debugf("received trace span : %s", attributesMap)
+		records = append(records, attributesMap)
+	}
+	err = adapter.writeToBuffer(buffer, records)
+	if err != nil {
+		return nil, err
+	}
 	return &v1beta1.ReportResult{}, nil
 }
 
-func (adapter *Adapter) writeToBuffer(attributeMap map[string]interface{}) {
-	jsonValue, err := json.Marshal(attributeMap)
+// writeToBuffer redacts, processes and writes the records of a request to the buffer as a whole, returning a
+// RESOURCE_EXHAUSTED error if the records were rejected since the buffer is full, so that Mixer does not wait on a
+// writer which cannot keep up and a retried request does not duplicate the records
+func (adapter *Adapter) writeToBuffer(buffer chan string, records []map[string]interface{}) error {
+	jsonValues := make([]string, 0, len(records))
+	for _, attributeMap := range records {
+		adapter.redactor.Redact(attributeMap)
+		adapter.processors.Process(attributeMap)
+		jsonValue, err := json.Marshal(attributeMap)
+		if err != nil {
+			return fmt.Errorf("could not marshal the record : %v", err)
+		}
+		jsonValues = append(jsonValues, string(jsonValue))
+	}
+	if len(jsonValues) == 0 {
+		return nil
+	}
+	err := adapter.overflow.PutAll(buffer, jsonValues)
 	if err != nil {
-		return
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+// decodeInstance decodes the dimensions of a metric instance in the allowlist of the session along with its name and
+// value, which take precedence over dimensions of the same name
+func decodeInstance(inst *metric.InstanceMsg, session *session) map[string]interface{} {
+	out := decodeDimensions(session.filter(inst.Dimensions))
+	out[InstanceNameKey] = inst.Name
+	if inst.Value != nil {
+		out[ValueKey] = decodeValue(inst.Value.GetValue())
+	}
+	return out
+}
+
+// decodeLogEntry decodes the variables of a log entry instance in the allowlist of the session along with its name,
+// timestamp, severity and monitored resource, which take precedence over variables of the same name
+func decodeLogEntry(inst *logentry.InstanceMsg, session *session) map[string]interface{} {
+	out := decodeDimensions(session.filter(inst.Variables))
+	out[InstanceNameKey] = inst.Name
+	out["timestamp"] = decodeTimestamp(inst.Timestamp)
+	out["severity"] = inst.Severity
+	out["monitoredResourceType"] = inst.MonitoredResourceType
+	out["monitoredResourceDimensions"] = decodeDimensions(inst.MonitoredResourceDimensions)
+	return out
+}
+
+// decodeTraceSpan decodes a trace span instance with the start and end times as milliseconds since the epoch, keeping
+// only the span tags in the allowlist of the session
+func decodeTraceSpan(inst *tracespan.InstanceMsg, session *session) map[string]interface{} {
+	return map[string]interface{}{
+		InstanceNameKey:  inst.Name,
+		"traceId":        inst.TraceId,
+		"spanId":         inst.SpanId,
+		"parentSpanId":   inst.ParentSpanId,
+		"spanName":       inst.SpanName,
+		"startTime":      decodeTimestamp(inst.StartTime),
+		"endTime":        decodeTimestamp(inst.EndTime),
+		"httpStatusCode": inst.HttpStatusCode,
+		"clientSpan":     inst.ClientSpan,
+		"spanTags":       decodeDimensions(session.filter(inst.SpanTags)),
 	}
-	adapter.buffer <- string(jsonValue)
 }
 
 func decodeDimensions(in map[string]*policy.Value) map[string]interface{} {
@@ -102,6 +260,8 @@ func decodeDimensions(in map[string]*policy.Value) map[string]interface{} {
 	return out
 }
 
+// decodeValue converts a policy value into its JSON representation. Durations are decoded as nanoseconds,
+// timestamps as milliseconds since the epoch and IP addresses as dotted strings.
 func decodeValue(in interface{}) interface{} {
 	switch t := in.(type) {
 	case *policy.Value_StringValue:
@@ -113,14 +273,48 @@ func decodeValue(in interface{}) interface{} {
 	case *policy.Value_BoolValue:
 		return t.BoolValue
 	case *policy.Value_IpAddressValue:
-		return t.IpAddressValue
+		return decodeIPAddress(t.IpAddressValue)
 	case *policy.Value_DurationValue:
-		return t.DurationValue.Value.Nanos
+		return decodeDuration(t.DurationValue)
+	case *policy.Value_TimestampValue:
+		return decodeTimestamp(t.TimestampValue)
+	case *policy.Value_EmailAddressValue:
+		return t.EmailAddressValue.GetValue()
+	case *policy.Value_DnsNameValue:
+		return t.DnsNameValue.GetValue()
+	case *policy.Value_UriValue:
+		return t.UriValue.GetValue()
+	case nil:
+		return nil
 	default:
 		return fmt.Sprintf("%v", in)
 	}
 }
 
+func decodeIPAddress(in *policy.IPAddress) string {
+	ip := in.GetValue()
+	if len(ip) == 0 {
+		return ""
+	}
+	return net.IP(ip).String()
+}
+
+func decodeDuration(in *policy.Duration) int64 {
+	duration := in.GetValue()
+	if duration == nil {
+		return 0
+	}
+	return duration.Seconds*int64(time.Second) + int64(duration.Nanos)
+}
+
+func decodeTimestamp(in *policy.TimeStamp) int64 {
+	timestamp := in.GetValue()
+	if timestamp == nil {
+		return 0
+	}
+	return timestamp.Seconds*1000 + int64(timestamp.Nanos)/int64(time.Millisecond)
+}
+
 // Addr returns the listening address of the server
 func (adapter *Adapter) Addr() string {
 	return adapter.listener.Addr().String()
@@ -144,16 +338,28 @@ func (adapter *Adapter) Close() error {
 }
 
 // New creates a new SP adapter that listens at provided port.
-func New(addr int, logger *zap.SugaredLogger, httpClient *http.Client, buffer chan string, config *Mixer) (Server, error) {
+func New(addr int, logger *zap.SugaredLogger, httpClient *http.Client, buffers *Buffers, config *Mixer) (Server, error) {
+	processors, err := processor.New(config.Processors)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the attribute processors : %v", err)
+	}
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", addr))
 	if err != nil {
 		return nil, fmt.Errorf("unable to listen on socket: %v", err)
 	}
 	adapter := &Adapter{
-		listener:   listener,
-		logger:     logger,
-		httpClient: httpClient,
-		buffer:     buffer,
+		listener:         listener,
+		logger:           logger,
+		httpClient:       httpClient,
+		buffer:           buffers.Metric,
+		logEntryBuffer:   buffers.LogEntry,
+		traceSpanBuffer:  buffers.TraceSpan,
+		pipelines:        buffers.Pipelines,
+		overflow:         buffers.Overflow,
+		processors:       processors,
+		redactor:         buffers.Redactor,
+		sessions:         make(map[string]*session),
+		sessionsByConfig: make(map[string]*session),
 	}
 	logger.Info("listening on ", adapter.Addr())
 	mixerTls := config.TLS
@@ -169,7 +375,14 @@ func New(addr int, logger *zap.SugaredLogger, httpClient *http.Client, buffer ch
 	} else {
 		adapter.server = grpc.NewServer()
 	}
+	v1beta1.RegisterInfrastructureBackendServer(adapter.server, adapter)
 	metric.RegisterHandleMetricServiceServer(adapter.server, adapter)
+	if adapter.logEntryBuffer != nil || len(adapter.pipelines) > 0 {
+		logentry.RegisterHandleLogEntryServiceServer(adapter.server, adapter)
+	}
+	if adapter.traceSpanBuffer != nil || len(adapter.pipelines) > 0 {
+		tracespan.RegisterHandleTraceSpanServiceServer(adapter.server, adapter)
+	}
 	return adapter, nil
 }
 
@@ -20,6 +20,7 @@ package adapter
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net"
@@ -28,10 +29,17 @@ import (
 	"testing"
 
 	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"istio.io/api/policy/v1beta1"
+	"istio.io/istio/mixer/template/logentry"
 	"istio.io/istio/mixer/template/metric"
+	"istio.io/istio/mixer/template/tracespan"
 
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/processor"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
 )
 
 type (
@@ -129,8 +137,8 @@ func TestNewAdapter(t *testing.T) {
 			Header:     make(http.Header),
 		}
 	})
-	mixer := &Mixer{&TLS{}}
-	adapter, err := New(AdapterPort, logger, client, buffer, mixer)
+	mixer := &Mixer{TLS: &TLS{}}
+	adapter, err := New(AdapterPort, logger, client, &Buffers{Metric: buffer}, mixer)
 	expectedStr := fmt.Sprintf("[::]:%d", AdapterPort)
 	if err != nil {
 		t.Errorf("Error while creating the adapter : %v", err)
@@ -165,7 +173,7 @@ func TestNewAdapterWithInvalidTlsData(t *testing.T) {
 		PrivateKey:    "./testdata/test.key",
 		CaCertificate: "./testdata/test.pem",
 	}}
-	adapter, err := New(AdapterPort, logger, client, buffer, tls)
+	adapter, err := New(AdapterPort, logger, client, &Buffers{Metric: buffer}, tls)
 	if adapter == nil {
 		t.Error("Received struct of the adapter is null")
 	}
@@ -189,7 +197,7 @@ func TestNewAdapterWithTLS(t *testing.T) {
 		PrivateKey:    "./testdata/adapter.key",
 		CaCertificate: "./testdata/ca.pem",
 	}}
-	adapter, err := New(AdapterPort, logger, client, buffer, tls)
+	adapter, err := New(AdapterPort, logger, client, &Buffers{Metric: buffer}, tls)
 	if adapter == nil {
 		t.Error("Received struct of the adapter is null")
 	}
@@ -231,3 +239,370 @@ func TestHandleMetric(t *testing.T) {
 		t.Errorf("Metrics could not be handled : %v", err)
 	}
 }
+
+func TestHandleMetricWithInstanceNameAndValue(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	buffer := make(chan string, 100)
+	wso2SpAdapter := &Adapter{
+		logger: logger,
+		buffer: buffer,
+	}
+	sizeInstance := &metric.InstanceMsg{
+		Name: "requestsize",
+		Dimensions: map[string]*v1beta1.Value{
+			"destinationComponent": {
+				Value: &v1beta1.Value_StringValue{StringValue: "hr"},
+			},
+			"value": {
+				Value: &v1beta1.Value_StringValue{StringValue: "dimension"},
+			},
+		},
+		Value: &v1beta1.Value{
+			Value: &v1beta1.Value_Int64Value{Int64Value: 1024},
+		},
+	}
+	countInstance := &metric.InstanceMsg{
+		Name: "requestcount",
+		Dimensions: map[string]*v1beta1.Value{
+			"destinationComponent": {
+				Value: &v1beta1.Value_StringValue{StringValue: "hr"},
+			},
+		},
+	}
+	_, err = wso2SpAdapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances: []*metric.InstanceMsg{sizeInstance, countInstance},
+	})
+	if err != nil {
+		t.Errorf("Metrics could not be handled : %v", err)
+	}
+	expectedRecords := []string{
+		`{"destinationComponent":"hr","instanceName":"requestsize","value":1024}`,
+		`{"destinationComponent":"hr","instanceName":"requestcount"}`,
+	}
+	if len(buffer) != len(expectedRecords) {
+		t.Fatalf("Expected %d records, but received %d", len(expectedRecords), len(buffer))
+	}
+	for _, expectedRecord := range expectedRecords {
+		record := <-buffer
+		if record != expectedRecord {
+			t.Errorf("Expected the record %s, but received %s", expectedRecord, record)
+		}
+	}
+}
+
+func TestHandleMetricWithProcessors(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	processors, err := processor.New([]*processor.Processor{
+		{Action: processor.RenameAction, Key: "destinationComponent", Target: "destination"},
+		{Action: processor.SetAction, Key: "cluster", Value: "dev"},
+		{Action: processor.DropAction, Key: InstanceNameKey},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the processors : %v", err)
+	}
+	buffer := make(chan string, 1)
+	wso2SpAdapter := &Adapter{
+		logger:     logger,
+		buffer:     buffer,
+		processors: processors,
+	}
+	_, err = wso2SpAdapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances: []*metric.InstanceMsg{
+			{
+				Name: "requestcount",
+				Dimensions: map[string]*v1beta1.Value{
+					"destinationComponent": {
+						Value: &v1beta1.Value_StringValue{StringValue: "hr"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Metrics could not be handled : %v", err)
+	}
+	expectedRecord := `{"cluster":"dev","destination":"hr"}`
+	if record := <-buffer; record != expectedRecord {
+		t.Errorf("Expected the record %s, but received %s", expectedRecord, record)
+	}
+}
+
+func TestHandleMetricWithRedaction(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	redactor, err := redaction.New(&redaction.Redaction{
+		Rules: []*redaction.Rule{{Keys: []string{"requestPath"}, QueryParameters: []string{"token"}}},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the redactor : %v", err)
+	}
+	processors, err := processor.New([]*processor.Processor{
+		{Action: processor.CopyAction, Key: "requestPath", Target: "path"},
+		{Action: processor.DropAction, Key: InstanceNameKey},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the processors : %v", err)
+	}
+	buffer := make(chan string, 1)
+	wso2SpAdapter := &Adapter{
+		logger:     logger,
+		buffer:     buffer,
+		processors: processors,
+		redactor:   redactor,
+	}
+	_, err = wso2SpAdapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances: []*metric.InstanceMsg{
+			{
+				Name: "requestcount",
+				Dimensions: map[string]*v1beta1.Value{
+					"requestPath": {
+						Value: &v1beta1.Value_StringValue{StringValue: "/pets?token=abc"},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Metrics could not be handled : %v", err)
+	}
+	expectedRecord := `{"path":"/pets?token=[REDACTED]","requestPath":"/pets?token=[REDACTED]"}`
+	if record := <-buffer; record != expectedRecord {
+		t.Errorf("Expected the record %s, but received %s", expectedRecord, record)
+	}
+}
+
+func TestHandleMetricWithFullBuffer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	overflowHandler, err := overflow.New(&overflow.Overflow{Policy: overflow.RejectPolicy}, logger)
+	if err != nil {
+		t.Fatalf("Could not create the overflow handler : %v", err)
+	}
+	buffer := make(chan string, 1)
+	wso2SpAdapter := &Adapter{
+		logger:   logger,
+		buffer:   buffer,
+		overflow: overflowHandler,
+	}
+	_, err = wso2SpAdapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances: []*metric.InstanceMsg{sampleInstance1, sampleInstance2},
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Expected the code %s, but received the error %v", codes.ResourceExhausted, err)
+	}
+	// The instances of the request are rejected as a whole, so that the retried request does not duplicate them
+	if len(buffer) != 0 {
+		t.Errorf("Expected no records in the buffer, but received %d", len(buffer))
+	}
+	if dropped := overflowHandler.Dropped()[overflow.RejectedReason]; dropped != 2 {
+		t.Errorf("Expected 2 rejected records, but received %d", dropped)
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *v1beta1.Value
+		expected string
+	}{
+		{
+			name:     "string",
+			value:    &v1beta1.Value{Value: &v1beta1.Value_StringValue{StringValue: "GET"}},
+			expected: `"GET"`,
+		},
+		{
+			name:     "int64",
+			value:    &v1beta1.Value{Value: &v1beta1.Value_Int64Value{Int64Value: 200}},
+			expected: `200`,
+		},
+		{
+			name:     "double",
+			value:    &v1beta1.Value{Value: &v1beta1.Value_DoubleValue{DoubleValue: 0.25}},
+			expected: `0.25`,
+		},
+		{
+			name:     "bool",
+			value:    &v1beta1.Value{Value: &v1beta1.Value_BoolValue{BoolValue: true}},
+			expected: `true`,
+		},
+		{
+			name: "ipv4 address",
+			value: &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{
+				IpAddressValue: &v1beta1.IPAddress{Value: []byte{10, 0, 1, 23}},
+			}},
+			expected: `"10.0.1.23"`,
+		},
+		{
+			name: "ipv4 address in 16 bytes",
+			value: &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{
+				IpAddressValue: &v1beta1.IPAddress{Value: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 255, 255, 192, 168, 0, 1}},
+			}},
+			expected: `"192.168.0.1"`,
+		},
+		{
+			name: "ipv6 address",
+			value: &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{
+				IpAddressValue: &v1beta1.IPAddress{Value: []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+			}},
+			expected: `"2001:db8::1"`,
+		},
+		{
+			name: "empty ip address",
+			value: &v1beta1.Value{Value: &v1beta1.Value_IpAddressValue{
+				IpAddressValue: &v1beta1.IPAddress{Value: []byte{}},
+			}},
+			expected: `""`,
+		},
+		{
+			name: "duration",
+			value: &v1beta1.Value{Value: &v1beta1.Value_DurationValue{
+				DurationValue: &v1beta1.Duration{Value: &types.Duration{Seconds: 1, Nanos: 500000000}},
+			}},
+			expected: `1500000000`,
+		},
+		{
+			name: "empty duration",
+			value: &v1beta1.Value{Value: &v1beta1.Value_DurationValue{
+				DurationValue: &v1beta1.Duration{},
+			}},
+			expected: `0`,
+		},
+		{
+			name: "timestamp",
+			value: &v1beta1.Value{Value: &v1beta1.Value_TimestampValue{
+				TimestampValue: &v1beta1.TimeStamp{Value: &types.Timestamp{Seconds: 1560000000, Nanos: 123456789}},
+			}},
+			expected: `1560000000123`,
+		},
+		{
+			name: "email address",
+			value: &v1beta1.Value{Value: &v1beta1.Value_EmailAddressValue{
+				EmailAddressValue: &v1beta1.EmailAddress{Value: "admin@cellery.io"},
+			}},
+			expected: `"admin@cellery.io"`,
+		},
+		{
+			name: "dns name",
+			value: &v1beta1.Value{Value: &v1beta1.Value_DnsNameValue{
+				DnsNameValue: &v1beta1.DNSName{Value: "hr--hr-service.default.svc.cluster.local"},
+			}},
+			expected: `"hr--hr-service.default.svc.cluster.local"`,
+		},
+		{
+			name: "uri",
+			value: &v1beta1.Value{Value: &v1beta1.Value_UriValue{
+				UriValue: &v1beta1.Uri{Value: "https://cellery.io/hr?id=1"},
+			}},
+			expected: `"https://cellery.io/hr?id=1"`,
+		},
+		{
+			name:     "unset",
+			value:    &v1beta1.Value{},
+			expected: `null`,
+		},
+	}
+	for _, test := range tests {
+		decoded, err := json.Marshal(decodeValue(test.value.GetValue()))
+		if err != nil {
+			t.Errorf("Could not marshal the decoded %s value : %v", test.name, err)
+			continue
+		}
+		if string(decoded) != test.expected {
+			t.Errorf("Expected the %s value to be decoded as %s, but received %s", test.name, test.expected,
+				string(decoded))
+		}
+	}
+}
+
+func TestHandleLogEntry(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	logEntryBuffer := make(chan string, 100)
+	wso2SpAdapter := &Adapter{
+		logger:         logger,
+		logEntryBuffer: logEntryBuffer,
+	}
+	_, err = wso2SpAdapter.HandleLogEntry(context.TODO(), &logentry.HandleLogEntryRequest{
+		Instances: []*logentry.InstanceMsg{
+			{
+				Name: "accesslog",
+				Variables: map[string]*v1beta1.Value{
+					"requestPath":  {Value: &v1beta1.Value_StringValue{StringValue: "/employees"}},
+					"responseCode": {Value: &v1beta1.Value_Int64Value{Int64Value: 200}},
+				},
+				Timestamp:             &v1beta1.TimeStamp{Value: &types.Timestamp{Seconds: 1560000000}},
+				Severity:              "Info",
+				MonitoredResourceType: "global",
+				MonitoredResourceDimensions: map[string]*v1beta1.Value{
+					"destinationComponent": {Value: &v1beta1.Value_StringValue{StringValue: "hr"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Log entries could not be handled : %v", err)
+	}
+	expectedRecord := `{"instanceName":"accesslog","monitoredResourceDimensions":{"destinationComponent":"hr"},` +
+		`"monitoredResourceType":"global","requestPath":"/employees","responseCode":200,"severity":"Info",` +
+		`"timestamp":1560000000000}`
+	if len(logEntryBuffer) != 1 {
+		t.Fatalf("Expected 1 record, but received %d", len(logEntryBuffer))
+	}
+	if record := <-logEntryBuffer; record != expectedRecord {
+		t.Errorf("Expected the record %s, but received %s", expectedRecord, record)
+	}
+}
+
+func TestHandleTraceSpan(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	traceSpanBuffer := make(chan string, 100)
+	wso2SpAdapter := &Adapter{
+		logger:          logger,
+		traceSpanBuffer: traceSpanBuffer,
+	}
+	_, err = wso2SpAdapter.HandleTraceSpan(context.TODO(), &tracespan.HandleTraceSpanRequest{
+		Instances: []*tracespan.InstanceMsg{
+			{
+				Name:           "mixerspan",
+				TraceId:        "463ac35c9f6413ad",
+				SpanId:         "72485a3953bb6124",
+				ParentSpanId:   "a2fb4a1d1a96d312",
+				SpanName:       "/employees",
+				StartTime:      &v1beta1.TimeStamp{Value: &types.Timestamp{Seconds: 1560000000}},
+				EndTime:        &v1beta1.TimeStamp{Value: &types.Timestamp{Seconds: 1560000000, Nanos: 25000000}},
+				HttpStatusCode: 200,
+				ClientSpan:     true,
+				SpanTags: map[string]*v1beta1.Value{
+					"http.method": {Value: &v1beta1.Value_StringValue{StringValue: "GET"}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Trace spans could not be handled : %v", err)
+	}
+	expectedRecord := `{"clientSpan":true,"endTime":1560000000025,"httpStatusCode":200,"instanceName":"mixerspan",` +
+		`"parentSpanId":"a2fb4a1d1a96d312","spanId":"72485a3953bb6124","spanName":"/employees",` +
+		`"spanTags":{"http.method":"GET"},"startTime":1560000000000,"traceId":"463ac35c9f6413ad"}`
+	if len(traceSpanBuffer) != 1 {
+		t.Fatalf("Expected 1 record, but received %d", len(traceSpanBuffer))
+	}
+	if record := <-traceSpanBuffer; record != expectedRecord {
+		t.Errorf("Expected the record %s, but received %s", expectedRecord, record)
+	}
+}
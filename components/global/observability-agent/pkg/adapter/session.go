@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/googleapis/google/rpc"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	policy "istio.io/api/policy/v1beta1"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/adapter/config"
+)
+
+const maxConfigSessions = 64
+
+type (
+	// session holds the settings of a handler. The records of the instances sent to a handler without any params are
+	// written to the buffer of their template, hence a nil session is used for them.
+	session struct {
+		buffer       chan string
+		dimensions   map[string]bool
+		samplingRate float64
+	}
+)
+
+// Validate validates the params of a handler
+func (adapter *Adapter) Validate(ctx context.Context, r *v1beta1.ValidateRequest) (*v1beta1.ValidateResponse, error) {
+	params, err := unmarshalParams(r.AdapterConfig)
+	if err == nil {
+		err = adapter.validateParams(params)
+	}
+	if err != nil {
+		return &v1beta1.ValidateResponse{Status: invalidArgumentStatus(err)}, nil
+	}
+	return &v1beta1.ValidateResponse{Status: &rpc.Status{Code: int32(rpc.OK)}}, nil
+}
+
+// CreateSession creates a session for the params of a handler
+func (adapter *Adapter) CreateSession(ctx context.Context,
+	r *v1beta1.CreateSessionRequest) (*v1beta1.CreateSessionResponse, error) {
+	session, err := adapter.newSession(r.AdapterConfig)
+	if err != nil {
+		return &v1beta1.CreateSessionResponse{Status: invalidArgumentStatus(err)}, nil
+	}
+	adapter.sessionsLock.Lock()
+	defer adapter.sessionsLock.Unlock()
+	adapter.initSessions()
+	adapter.lastSessionID++
+	sessionID := strconv.FormatInt(adapter.lastSessionID, 10)
+	adapter.sessions[sessionID] = session
+	adapter.logger.Infof("Created session %s", sessionID)
+	return &v1beta1.CreateSessionResponse{SessionId: sessionID, Status: &rpc.Status{Code: int32(rpc.OK)}}, nil
+}
+
+// CloseSession closes a session created for the params of a handler
+func (adapter *Adapter) CloseSession(ctx context.Context,
+	r *v1beta1.CloseSessionRequest) (*v1beta1.CloseSessionResponse, error) {
+	adapter.sessionsLock.Lock()
+	defer adapter.sessionsLock.Unlock()
+	if _, ok := adapter.sessions[r.SessionId]; !ok {
+		return &v1beta1.CloseSessionResponse{Status: &rpc.Status{
+			Code:    int32(rpc.NOT_FOUND),
+			Message: fmt.Sprintf("unknown session %s", r.SessionId),
+		}}, nil
+	}
+	delete(adapter.sessions, r.SessionId)
+	adapter.logger.Infof("Closed session %s", r.SessionId)
+	return &v1beta1.CloseSessionResponse{Status: &rpc.Status{Code: int32(rpc.OK)}}, nil
+}
+
+// sessionFor returns the session for the adapter config sent with a request. Mixer sends the ID of the session
+// created for a session based handler, and the params with each request otherwise, hence a session is kept for up to
+// maxConfigSessions distinct params as well.
+func (adapter *Adapter) sessionFor(adapterConfig *types.Any) (*session, error) {
+	if adapterConfig == nil || len(adapterConfig.Value) == 0 {
+		return nil, nil
+	}
+	if sessionID, ok := sessionIDOf(adapterConfig); ok {
+		adapter.sessionsLock.RLock()
+		defer adapter.sessionsLock.RUnlock()
+		session, ok := adapter.sessions[sessionID]
+		if !ok {
+			return nil, fmt.Errorf("unknown session %s", sessionID)
+		}
+		return session, nil
+	}
+	key := configKey(adapterConfig)
+	adapter.sessionsLock.RLock()
+	session, ok := adapter.sessionsByConfig[key]
+	adapter.sessionsLock.RUnlock()
+	if ok {
+		return session, nil
+	}
+	session, err := adapter.newSession(adapterConfig)
+	if err != nil {
+		return nil, err
+	}
+	adapter.sessionsLock.Lock()
+	defer adapter.sessionsLock.Unlock()
+	adapter.initSessions()
+	if len(adapter.sessionsByConfig) >= maxConfigSessions {
+		// Params which are no longer used by any handler are not known, hence an arbitrary session makes way
+		for configKey := range adapter.sessionsByConfig {
+			delete(adapter.sessionsByConfig, configKey)
+			break
+		}
+	}
+	adapter.sessionsByConfig[key] = session
+	return session, nil
+}
+
+func (adapter *Adapter) initSessions() {
+	if adapter.sessions == nil {
+		adapter.sessions = make(map[string]*session)
+	}
+	if adapter.sessionsByConfig == nil {
+		adapter.sessionsByConfig = make(map[string]*session)
+	}
+}
+
+func (adapter *Adapter) newSession(adapterConfig *types.Any) (*session, error) {
+	params, err := unmarshalParams(adapterConfig)
+	if err != nil {
+		return nil, err
+	}
+	err = adapter.validateParams(params)
+	if err != nil {
+		return nil, err
+	}
+	session := &session{
+		buffer:       adapter.pipelines[params.Pipeline],
+		samplingRate: params.SamplingRate,
+	}
+	if len(params.Dimensions) > 0 {
+		session.dimensions = make(map[string]bool, len(params.Dimensions))
+		for _, dimension := range params.Dimensions {
+			session.dimensions[dimension] = true
+		}
+	}
+	return session, nil
+}
+
+func (adapter *Adapter) validateParams(params *config.Params) error {
+	if params.Pipeline != "" {
+		if _, ok := adapter.pipelines[params.Pipeline]; !ok {
+			return fmt.Errorf("unknown pipeline %s", params.Pipeline)
+		}
+	}
+	for _, dimension := range params.Dimensions {
+		if dimension == "" {
+			return fmt.Errorf("dimensions should not be empty")
+		}
+	}
+	if math.IsNaN(params.SamplingRate) || params.SamplingRate < 0 || params.SamplingRate > 1 {
+		return fmt.Errorf("sampling rate should be between 0 and 1, but received %v", params.SamplingRate)
+	}
+	return nil
+}
+
+// random returns a random number in [0, 1) used for sampling the instances
+func (adapter *Adapter) random() float64 {
+	if adapter.sample != nil {
+		return adapter.sample()
+	}
+	return rand.Float64()
+}
+
+// bufferOr returns the buffer of the pipeline of the session, or the given buffer of the template if the session
+// does not have a pipeline
+func (session *session) bufferOr(templateBuffer chan string) chan string {
+	if session == nil || session.buffer == nil {
+		return templateBuffer
+	}
+	return session.buffer
+}
+
+// sampled returns whether an instance should be written for the given random number in [0, 1)
+func (session *session) sampled(random float64) bool {
+	if session == nil || session.samplingRate == 0 {
+		return true
+	}
+	return random < session.samplingRate
+}
+
+// filter returns the dimensions in the allowlist of the session
+func (session *session) filter(in map[string]*policy.Value) map[string]*policy.Value {
+	if session == nil || session.dimensions == nil {
+		return in
+	}
+	out := make(map[string]*policy.Value, len(session.dimensions))
+	for k, v := range in {
+		if session.dimensions[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// sessionIDOf returns the session ID sent as the adapter config, which is any config other than the params
+func sessionIDOf(adapterConfig *types.Any) (string, bool) {
+	if strings.HasSuffix(adapterConfig.TypeUrl, proto.MessageName(&config.Params{})) {
+		return "", false
+	}
+	return string(adapterConfig.Value), true
+}
+
+func configKey(adapterConfig *types.Any) string {
+	return adapterConfig.TypeUrl + "\x00" + string(adapterConfig.Value)
+}
+
+func invalidArgumentStatus(err error) *rpc.Status {
+	return &rpc.Status{Code: int32(rpc.INVALID_ARGUMENT), Message: err.Error()}
+}
+
+// unmarshalParams unmarshals the handler params sent as the adapter config
+func unmarshalParams(adapterConfig *types.Any) (*config.Params, error) {
+	params := &config.Params{}
+	if adapterConfig == nil {
+		return params, nil
+	}
+	err := params.Unmarshal(adapterConfig.Value)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal the params : %v", err)
+	}
+	return params, nil
+}
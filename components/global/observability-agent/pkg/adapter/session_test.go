@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/googleapis/google/rpc"
+	"github.com/gogo/protobuf/types"
+	"istio.io/api/mixer/adapter/model/v1beta1"
+	policy "istio.io/api/policy/v1beta1"
+	"istio.io/istio/mixer/template/metric"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/adapter/config"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+const (
+	paramsTypeUrl = "type.googleapis.com/adapter.config.Params"
+	// sessionTypeUrl is the type URL Mixer sends along with the session ID to the session based handlers
+	sessionTypeUrl = "google.protobuf.Any.type_url"
+)
+
+func encodeParams(t *testing.T, params *config.Params) *types.Any {
+	value, err := params.Marshal()
+	if err != nil {
+		t.Fatalf("Could not marshal the params : %v", err)
+	}
+	return &types.Any{TypeUrl: paramsTypeUrl, Value: value}
+}
+
+func encodeSessionID(sessionID string) *types.Any {
+	return &types.Any{TypeUrl: sessionTypeUrl, Value: []byte(sessionID)}
+}
+
+func newSessionTestAdapter(t *testing.T) *Adapter {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	return &Adapter{
+		logger: logger,
+		buffer: make(chan string, 100),
+		pipelines: map[string]chan string{
+			"audit": make(chan string, 100),
+		},
+	}
+}
+
+func TestUnmarshalParams(t *testing.T) {
+	params, err := unmarshalParams(encodeParams(t, &config.Params{
+		Pipeline:     "audit",
+		Dimensions:   []string{"destinationComponent", "responseCode"},
+		SamplingRate: 0.25,
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error when unmarshalling the params : %v", err)
+	}
+	if params.Pipeline != "audit" || len(params.Dimensions) != 2 || params.Dimensions[0] != "destinationComponent" ||
+		params.Dimensions[1] != "responseCode" || params.SamplingRate != 0.25 {
+		t.Errorf("Unexpected params %+v", params)
+	}
+
+	_, err = unmarshalParams(&types.Any{TypeUrl: paramsTypeUrl, Value: []byte{0x0a, 0x05, 'a'}})
+	expectedErr := "could not unmarshal the params : unexpected EOF"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error %q, but received %v", expectedErr, err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	adapter := newSessionTestAdapter(t)
+	tests := []struct {
+		params          *config.Params
+		expectedCode    rpc.Code
+		expectedMessage string
+	}{
+		{
+			params:       &config.Params{Pipeline: "audit", Dimensions: []string{"responseCode"}, SamplingRate: 0.5},
+			expectedCode: rpc.OK,
+		},
+		{
+			params:       &config.Params{},
+			expectedCode: rpc.OK,
+		},
+		{
+			params:          &config.Params{Pipeline: "billing"},
+			expectedCode:    rpc.INVALID_ARGUMENT,
+			expectedMessage: "unknown pipeline billing",
+		},
+		{
+			params:          &config.Params{Dimensions: []string{""}},
+			expectedCode:    rpc.INVALID_ARGUMENT,
+			expectedMessage: "dimensions should not be empty",
+		},
+		{
+			params:          &config.Params{SamplingRate: 1.5},
+			expectedCode:    rpc.INVALID_ARGUMENT,
+			expectedMessage: "sampling rate should be between 0 and 1, but received 1.5",
+		},
+	}
+	for _, test := range tests {
+		res, err := adapter.Validate(context.TODO(),
+			&v1beta1.ValidateRequest{AdapterConfig: encodeParams(t, test.params)})
+		if err != nil {
+			t.Errorf("Unexpected error when validating %+v : %v", test.params, err)
+			continue
+		}
+		if res.Status.Code != int32(test.expectedCode) || res.Status.Message != test.expectedMessage {
+			t.Errorf("Expected the status %v %q for %+v, but received %v %q", test.expectedCode,
+				test.expectedMessage, test.params, rpc.Code(res.Status.Code), res.Status.Message)
+		}
+	}
+}
+
+func TestSessionRouting(t *testing.T) {
+	adapter := newSessionTestAdapter(t)
+	createRes, err := adapter.CreateSession(context.TODO(), &v1beta1.CreateSessionRequest{
+		AdapterConfig: encodeParams(t, &config.Params{Pipeline: "audit", Dimensions: []string{"destinationComponent"}}),
+	})
+	if err != nil || createRes.Status.Code != int32(rpc.OK) {
+		t.Fatalf("Could not create the session : %v, %v", err, createRes)
+	}
+	if createRes.SessionId != "1" {
+		t.Errorf("Expected the session ID 1, but received %s", createRes.SessionId)
+	}
+	instance := &metric.InstanceMsg{
+		Name: "requestcount",
+		Dimensions: map[string]*policy.Value{
+			"destinationComponent": {Value: &policy.Value_StringValue{StringValue: "hr"}},
+			"requestPath":          {Value: &policy.Value_StringValue{StringValue: "/employees"}},
+		},
+	}
+	// Mixer sends the ID of the session instead of the params with the requests to a session based handler
+	_, err = adapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances:     []*metric.InstanceMsg{instance},
+		AdapterConfig: encodeSessionID(createRes.SessionId),
+	})
+	if err != nil {
+		t.Errorf("Metrics could not be handled : %v", err)
+	}
+	_, err = adapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances: []*metric.InstanceMsg{instance},
+	})
+	if err != nil {
+		t.Errorf("Metrics could not be handled : %v", err)
+	}
+	expectedRecord := `{"destinationComponent":"hr","instanceName":"requestcount"}`
+	if record := <-adapter.pipelines["audit"]; record != expectedRecord {
+		t.Errorf("Expected the record %s in the pipeline, but received %s", expectedRecord, record)
+	}
+	expectedRecord = `{"destinationComponent":"hr","instanceName":"requestcount","requestPath":"/employees"}`
+	if record := <-adapter.buffer; record != expectedRecord {
+		t.Errorf("Expected the record %s in the metric buffer, but received %s", expectedRecord, record)
+	}
+
+	closeRes, err := adapter.CloseSession(context.TODO(), &v1beta1.CloseSessionRequest{SessionId: "1"})
+	if err != nil || closeRes.Status.Code != int32(rpc.OK) {
+		t.Errorf("Could not close the session : %v, %v", err, closeRes)
+	}
+	if len(adapter.sessions) != 0 {
+		t.Errorf("Expected the session to be removed, but %d sessions remain", len(adapter.sessions))
+	}
+	_, err = adapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances:     []*metric.InstanceMsg{instance},
+		AdapterConfig: encodeSessionID("1"),
+	})
+	expectedErr := "unknown session 1"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error %q, but received %v", expectedErr, err)
+	}
+	closeRes, err = adapter.CloseSession(context.TODO(), &v1beta1.CloseSessionRequest{SessionId: "1"})
+	if err != nil || closeRes.Status.Code != int32(rpc.NOT_FOUND) {
+		t.Errorf("Expected the status NOT_FOUND when closing an unknown session, but received %v, %v", err,
+			closeRes)
+	}
+}
+
+func TestSessionSampling(t *testing.T) {
+	adapter := newSessionTestAdapter(t)
+	randoms := []float64{0.1, 0.9, 0.2, 0.5}
+	adapter.sample = func() float64 {
+		random := randoms[0]
+		randoms = randoms[1:]
+		return random
+	}
+	instances := make([]*metric.InstanceMsg, len(randoms))
+	for i := range instances {
+		instances[i] = &metric.InstanceMsg{Name: "requestcount"}
+	}
+	_, err := adapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances:     instances,
+		AdapterConfig: encodeParams(t, &config.Params{SamplingRate: 0.5}),
+	})
+	if err != nil {
+		t.Errorf("Metrics could not be handled : %v", err)
+	}
+	if len(adapter.buffer) != 2 {
+		t.Errorf("Expected 2 sampled records, but received %d", len(adapter.buffer))
+	}
+
+	_, err = adapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+		Instances:     instances,
+		AdapterConfig: encodeParams(t, &config.Params{Pipeline: "billing"}),
+	})
+	expectedErr := "unknown pipeline billing"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error %q, but received %v", expectedErr, err)
+	}
+}
+
+func TestSessionsByConfigAreBounded(t *testing.T) {
+	adapter := newSessionTestAdapter(t)
+	instance := &metric.InstanceMsg{Name: "requestcount"}
+	for i := 0; i <= maxConfigSessions; i++ {
+		_, err := adapter.HandleMetric(context.TODO(), &metric.HandleMetricRequest{
+			Instances:     []*metric.InstanceMsg{instance},
+			AdapterConfig: encodeParams(t, &config.Params{SamplingRate: float64(i+1) / 100}),
+		})
+		if err != nil {
+			t.Errorf("Metrics could not be handled : %v", err)
+		}
+	}
+	if len(adapter.sessionsByConfig) != maxConfigSessions {
+		t.Errorf("Expected %d sessions for the params, but received %d", maxConfigSessions,
+			len(adapter.sessionsByConfig))
+	}
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package aggregator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWindowSeconds = 60
+	defaultValueKey      = "responseDurationNanoSec"
+
+	// The keys of the aggregated records, in addition to the keys by which the records are grouped
+	WindowStartKey = "windowStart"
+	WindowEndKey   = "windowEnd"
+	CountKey       = "count"
+	SumKey         = "sum"
+	MinKey         = "min"
+	MaxKey         = "max"
+	HistogramKey   = "histogram"
+
+	infiniteBucket = "+Inf"
+)
+
+var (
+	defaultKeys = []string{"instanceName", "sourceInstance", "sourceComponent", "destinationInstance",
+		"destinationComponent", "requestMethod", "responseCode"}
+	// defaultBuckets are the upper bounds of the latency histogram from 5ms to 10s, in nanoseconds
+	defaultBuckets = []float64{5e6, 10e6, 25e6, 50e6, 100e6, 250e6, 500e6, 1e9, 2.5e9, 5e9, 10e9}
+)
+
+type (
+	// Aggregation is the configuration of the tumbling windows the records are aggregated into
+	Aggregation struct {
+		// Keys are the keys by which the records are grouped
+		Keys []string `json:"keys"`
+		// ValueKey is the key of the value summarized by the sum, the min, the max and the histogram
+		ValueKey      string `json:"valueKey"`
+		WindowSeconds int    `json:"windowSeconds"`
+		// Buckets are the upper bounds of the histogram buckets in the unit of the value
+		Buckets []float64 `json:"buckets"`
+	}
+
+	// Aggregator reads the records from the input and writes one record per group to the output at the end of each
+	// window, with the count of the records and the summary of their values
+	Aggregator struct {
+		Logger      *zap.SugaredLogger
+		Input       chan string
+		Output      chan string
+		keys        []string
+		valueKey    string
+		window      time.Duration
+		buckets     []float64
+		windowStart time.Time
+		groups      map[string]*group
+	}
+
+	group struct {
+		attributes map[string]interface{}
+		count      int64
+		values     int64
+		sum        float64
+		min        float64
+		max        float64
+		histogram  []int64
+	}
+)
+
+func New(config *Aggregation, logger *zap.SugaredLogger, input chan string, output chan string) (*Aggregator, error) {
+	if config.WindowSeconds < 0 {
+		return nil, fmt.Errorf("the aggregation window should not be negative")
+	}
+	aggregator := &Aggregator{
+		Logger:   logger,
+		Input:    input,
+		Output:   output,
+		keys:     config.Keys,
+		valueKey: config.ValueKey,
+		window:   time.Duration(config.WindowSeconds) * time.Second,
+		buckets:  config.Buckets,
+		groups:   make(map[string]*group),
+	}
+	if len(aggregator.keys) == 0 {
+		aggregator.keys = defaultKeys
+	}
+	if aggregator.valueKey == "" {
+		aggregator.valueKey = defaultValueKey
+	}
+	if aggregator.window == 0 {
+		aggregator.window = defaultWindowSeconds * time.Second
+	}
+	if len(aggregator.buckets) == 0 {
+		aggregator.buckets = defaultBuckets
+	}
+	if !sort.Float64sAreSorted(aggregator.buckets) {
+		return nil, fmt.Errorf("the histogram buckets should be in the ascending order")
+	}
+	return aggregator, nil
+}
+
+// Run aggregates the records into windows aligned to the window size, so that the windows of all the agents match.
+// When stopped, the records left in the input are added and the partial window is written, waiting for space in the
+// output. Hence the output should be drained until Run returns, so that the partial window is persisted along with
+// the buffered records.
+func (aggregator *Aggregator) Run(stopCh <-chan struct{}) {
+	aggregator.Logger.Info("Aggregator started")
+	aggregator.windowStart = time.Now().Truncate(aggregator.window)
+	timer := time.NewTimer(time.Until(aggregator.windowStart.Add(aggregator.window)))
+	defer timer.Stop()
+	for {
+		select {
+		case record := <-aggregator.Input:
+			aggregator.add(record)
+		case <-timer.C:
+			windowEnd := aggregator.windowStart.Add(aggregator.window)
+			aggregator.flush(windowEnd)
+			timer.Reset(time.Until(windowEnd.Add(aggregator.window)))
+		case <-stopCh:
+			aggregator.drainInput()
+			aggregator.flush(time.Now())
+			return
+		}
+	}
+}
+
+// drainInput adds the records written to the input before the aggregator was stopped
+func (aggregator *Aggregator) drainInput() {
+	for {
+		select {
+		case record := <-aggregator.Input:
+			aggregator.add(record)
+		default:
+			return
+		}
+	}
+}
+
+// add adds a record to the group of its keys. The records without a numeric value are only counted.
+func (aggregator *Aggregator) add(record string) {
+	attributes := make(map[string]interface{})
+	err := json.Unmarshal([]byte(record), &attributes)
+	if err != nil {
+		aggregator.Logger.Warnf("Could not unmarshal the record %s : %v", record, err)
+		return
+	}
+	groupKeys := make([]string, len(aggregator.keys))
+	for i, key := range aggregator.keys {
+		if value, ok := attributes[key]; ok {
+			groupKeys[i] = fmt.Sprint(value)
+		}
+	}
+	groupKey := strings.Join(groupKeys, "\x00")
+	recordGroup, ok := aggregator.groups[groupKey]
+	if !ok {
+		recordGroup = &group{
+			attributes: make(map[string]interface{}),
+			histogram:  make([]int64, len(aggregator.buckets)+1),
+		}
+		for _, key := range aggregator.keys {
+			if value, ok := attributes[key]; ok {
+				recordGroup.attributes[key] = value
+			}
+		}
+		aggregator.groups[groupKey] = recordGroup
+	}
+	recordGroup.count++
+	value, ok := attributes[aggregator.valueKey].(float64)
+	if !ok {
+		return
+	}
+	if recordGroup.values == 0 || value < recordGroup.min {
+		recordGroup.min = value
+	}
+	if recordGroup.values == 0 || value > recordGroup.max {
+		recordGroup.max = value
+	}
+	recordGroup.values++
+	recordGroup.sum += value
+	recordGroup.histogram[sort.SearchFloat64s(aggregator.buckets, value)]++
+}
+
+// flush writes the records of the groups of the current window to the output and starts the next window
+func (aggregator *Aggregator) flush(windowEnd time.Time) {
+	for _, recordGroup := range aggregator.groups {
+		jsonValue, err := json.Marshal(aggregator.toRecord(recordGroup, windowEnd))
+		if err != nil {
+			aggregator.Logger.Errorf("Could not marshal the aggregated record : %v", err)
+			continue
+		}
+		aggregator.Output <- string(jsonValue)
+	}
+	aggregator.groups = make(map[string]*group)
+	aggregator.windowStart = windowEnd
+}
+
+func (aggregator *Aggregator) toRecord(recordGroup *group, windowEnd time.Time) map[string]interface{} {
+	record := make(map[string]interface{}, len(recordGroup.attributes)+7)
+	for key, value := range recordGroup.attributes {
+		record[key] = value
+	}
+	record[WindowStartKey] = aggregator.windowStart.UnixNano() / int64(time.Millisecond)
+	record[WindowEndKey] = windowEnd.UnixNano() / int64(time.Millisecond)
+	record[CountKey] = recordGroup.count
+	if recordGroup.values == 0 {
+		return record
+	}
+	record[SumKey] = recordGroup.sum
+	record[MinKey] = recordGroup.min
+	record[MaxKey] = recordGroup.max
+	// The number of values in each bucket, by the upper bound of the bucket
+	histogram := make(map[string]int64)
+	for i, count := range recordGroup.histogram {
+		if count == 0 {
+			continue
+		}
+		bucket := infiniteBucket
+		if i < len(aggregator.buckets) {
+			bucket = strconv.FormatFloat(aggregator.buckets[i], 'f', -1, 64)
+		}
+		histogram[bucket] = count
+	}
+	record[HistogramKey] = histogram
+	return record
+}
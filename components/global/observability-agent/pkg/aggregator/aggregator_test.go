@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package aggregator
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+func TestAggregate(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	output := make(chan string, 10)
+	aggregator, err := New(&Aggregation{
+		Keys:     []string{"destinationComponent", "responseCode"},
+		ValueKey: "duration",
+		Buckets:  []float64{10, 100},
+	}, logger, nil, output)
+	if err != nil {
+		t.Fatalf("Could not create the aggregator : %v", err)
+	}
+	aggregator.windowStart = time.Unix(1559000000, 0)
+	records := []string{
+		`{"destinationComponent":"hr","responseCode":200,"duration":5}`,
+		`{"destinationComponent":"hr","responseCode":200,"duration":10,"requestPath":"/employees"}`,
+		`{"destinationComponent":"hr","responseCode":200,"duration":250}`,
+		`{"destinationComponent":"hr","responseCode":500}`,
+		`not a record`,
+	}
+	for _, record := range records {
+		aggregator.add(record)
+	}
+	aggregator.flush(time.Unix(1559000060, 0))
+	if len(output) != 2 {
+		t.Fatalf("Expected 2 aggregated records, but received %d", len(output))
+	}
+	expectedRecords := map[float64]map[string]interface{}{
+		200: {
+			"destinationComponent": "hr",
+			"responseCode":         float64(200),
+			WindowStartKey:         float64(1559000000000),
+			WindowEndKey:           float64(1559000060000),
+			CountKey:               float64(3),
+			SumKey:                 float64(265),
+			MinKey:                 float64(5),
+			MaxKey:                 float64(250),
+			HistogramKey:           map[string]interface{}{"10": float64(2), "+Inf": float64(1)},
+		},
+		500: {
+			"destinationComponent": "hr",
+			"responseCode":         float64(500),
+			WindowStartKey:         float64(1559000000000),
+			WindowEndKey:           float64(1559000060000),
+			CountKey:               float64(1),
+		},
+	}
+	for i := 0; i < 2; i++ {
+		record := make(map[string]interface{})
+		err = json.Unmarshal([]byte(<-output), &record)
+		if err != nil {
+			t.Fatalf("Could not unmarshal the aggregated record : %v", err)
+		}
+		expectedRecord := expectedRecords[record["responseCode"].(float64)]
+		if !reflect.DeepEqual(record, expectedRecord) {
+			t.Errorf("Expected the record %v, but received %v", expectedRecord, record)
+		}
+	}
+	if len(aggregator.groups) != 0 || !aggregator.windowStart.Equal(time.Unix(1559000060, 0)) {
+		t.Errorf("Expected the next window to start empty at the end of the previous window")
+	}
+}
+
+func TestRun(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	input := make(chan string, 2)
+	output := make(chan string, 1)
+	aggregator, err := New(&Aggregation{WindowSeconds: 3600}, logger, input, output)
+	if err != nil {
+		t.Fatalf("Could not create the aggregator : %v", err)
+	}
+	// The records left in the input when stopped should be aggregated into the partial window
+	input <- `{"destinationComponent":"hr","responseDurationNanoSec":2000000}`
+	input <- `{"destinationComponent":"hr","responseDurationNanoSec":3000000}`
+	stopCh := make(chan struct{})
+	close(stopCh)
+	aggregator.Run(stopCh)
+	record := make(map[string]interface{})
+	err = json.Unmarshal([]byte(<-output), &record)
+	if err != nil {
+		t.Fatalf("Could not unmarshal the aggregated record : %v", err)
+	}
+	if record[CountKey] != float64(2) || !reflect.DeepEqual(record[HistogramKey],
+		map[string]interface{}{"5000000": float64(2)}) {
+		t.Errorf("Unexpected aggregated record %v", record)
+	}
+}
+
+func TestNewWithInvalidConfig(t *testing.T) {
+	tests := []struct {
+		config      *Aggregation
+		expectedErr string
+	}{
+		{config: &Aggregation{WindowSeconds: -1}, expectedErr: "the aggregation window should not be negative"},
+		{config: &Aggregation{Buckets: []float64{100, 10}},
+			expectedErr: "the histogram buckets should be in the ascending order"},
+	}
+	for _, test := range tests {
+		_, err := New(test.config, nil, nil, nil)
+		if err == nil || err.Error() != test.expectedErr {
+			t.Errorf("Expected the error %s, but received %v", test.expectedErr, err)
+		}
+	}
+}
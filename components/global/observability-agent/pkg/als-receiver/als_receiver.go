@@ -0,0 +1,320 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package als_receiver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+	als "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+)
+
+const (
+	DefaultPort int = 9093
+
+	inboundReporter  = "inbound"
+	outboundReporter = "outbound"
+
+	cellLabel      = "mesh.cellery.io/cell"
+	compositeLabel = "mesh.cellery.io/composite"
+	componentLabel = "mesh.cellery.io/component"
+	cellKind       = "Cell"
+	compositeKind  = "Composite"
+
+	traceIdHeader      = "x-b3-traceid"
+	spanIdHeader       = "x-b3-spanid"
+	parentSpanIdHeader = "x-b3-parentspanid"
+	// The Istio metadata exchange adds the metadata of the proxy to the requests it sends and the responses it
+	// returns, which identifies the peer of the reporter
+	peerMetadataHeader   = "x-envoy-peer-metadata"
+	peerMetadataIdHeader = "x-envoy-peer-metadata-id"
+)
+
+type (
+	// AccessLogService is the configuration of the receiver of the Envoy access logs. The access log config of the
+	// proxies should log the x-envoy-peer-metadata and x-envoy-peer-metadata-id request and response headers, so
+	// that the peer workloads are known. Otherwise, the addresses of the peers are used in place of their pods.
+	AccessLogService struct {
+		Port int `json:"port"`
+	}
+
+	// Receiver implements the Envoy Access Log Service, and writes the HTTP access log entries to the buffer using
+	// the same attributes as the metrics received by the Mixer adapter
+	Receiver struct {
+		listener net.Listener
+		server   *grpc.Server
+		logger   *zap.SugaredLogger
+		buffer   chan string
+		overflow *overflow.Handler
+		redactor *redaction.Redactor
+	}
+
+	// workload is the pod reporting the access log entries, or its peer
+	workload struct {
+		namespace    string
+		instance     string
+		instanceKind string
+		component    string
+		pod          string
+	}
+)
+
+// New creates a new receiver of the Envoy access logs that listens at the port of the config
+func New(config *AccessLogService, logger *zap.SugaredLogger, buffer chan string, overflowHandler *overflow.Handler,
+	redactor *redaction.Redactor) (*Receiver, error) {
+	port := config.Port
+	if port == 0 {
+		port = DefaultPort
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on socket: %v", err)
+	}
+	receiver := &Receiver{
+		listener: listener,
+		server:   grpc.NewServer(),
+		logger:   logger,
+		buffer:   buffer,
+		overflow: overflowHandler,
+		redactor: redactor,
+	}
+	als.RegisterAccessLogServiceServer(receiver.server, receiver)
+	logger.Info("Access log service listening on ", receiver.Addr())
+	return receiver, nil
+}
+
+// Addr returns the listening address of the server
+func (receiver *Receiver) Addr() string {
+	return receiver.listener.Addr().String()
+}
+
+// Run starts the server run
+func (receiver *Receiver) Run(errCh chan error) {
+	errCh <- receiver.server.Serve(receiver.listener)
+	_ = receiver.Close()
+}
+
+// Close gracefully shuts down the server
+func (receiver *Receiver) Close() error {
+	if receiver.server != nil {
+		receiver.server.GracefulStop()
+	}
+	if receiver.listener != nil {
+		_ = receiver.listener.Close()
+	}
+	return nil
+}
+
+// StreamAccessLogs receives the access log entries streamed by an Envoy proxy. The proxy identifies itself only in
+// the first message of the stream, hence the reporter is kept for the rest of the stream. Envoy does not resend the
+// entries, hence the entries which do not fit in the buffer are only counted by the overflow handler.
+func (receiver *Receiver) StreamAccessLogs(stream als.AccessLogService_StreamAccessLogsServer) error {
+	reporter := &workload{}
+	for {
+		message, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&als.StreamAccessLogsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+		if message.Identifier != nil {
+			reporter = nodeWorkload(message.Identifier.Node)
+		}
+		httpLogs := message.GetHttpLogs()
+		if httpLogs == nil {
+			continue
+		}
+		for _, entry := range httpLogs.LogEntry {
+			record := toRecord(reporter, entry)
+			receiver.redactor.Redact(record)
+			receiver.logger.Debugf("received access log entry : %s", record)
+			jsonValue, err := json.Marshal(record)
+			if err != nil {
+				receiver.logger.Errorf("Could not marshal the access log entry : %v", err)
+				continue
+			}
+			err = receiver.overflow.Put(receiver.buffer, string(jsonValue))
+			if err != nil {
+				receiver.logger.Debugf("Could not buffer the access log entry : %v", err)
+			}
+		}
+	}
+}
+
+// toRecord maps an HTTP access log entry to the attributes of the telemetry stream. The reporter is the source of
+// the outbound requests and the destination of the inbound requests, while the peer is the other end. The attributes
+// which cannot be derived from the entry are left out.
+func toRecord(reporter *workload, entry *accesslog.HTTPAccessLogEntry) map[string]interface{} {
+	record := make(map[string]interface{})
+	common := entry.GetCommonProperties()
+	request := entry.GetRequest()
+	response := entry.GetResponse()
+	reporterKind, peer := upstreamPeer(common.GetUpstreamCluster())
+	source, destination := reporter, peer
+	if reporterKind == inboundReporter {
+		// The downstream proxy sends its metadata with the request
+		peer = headerWorkload(request.GetRequestHeaders(), peer)
+		if peer.pod == "" {
+			peer.pod = socketAddress(common.GetDownstreamRemoteAddress())
+		}
+		source, destination = peer, reporter
+	} else if reporterKind == outboundReporter {
+		// The upstream proxy returns its metadata with the response
+		peer = headerWorkload(response.GetResponseHeaders(), peer)
+		if peer.pod == "" {
+			peer.pod = socketAddress(common.GetUpstreamRemoteAddress())
+		}
+		destination = peer
+	}
+	putString(record, "contextReporterKind", reporterKind)
+	putString(record, "sourceNamespace", source.namespace)
+	putString(record, "sourceInstance", source.instance)
+	putString(record, "sourceInstanceKind", source.instanceKind)
+	putString(record, "sourceComponent", source.component)
+	putString(record, "sourcePod", source.pod)
+	putString(record, "destinationNamespace", destination.namespace)
+	putString(record, "destinationInstance", destination.instance)
+	putString(record, "destinationInstanceKind", destination.instanceKind)
+	putString(record, "destinationComponent", destination.component)
+	putString(record, "destinationPod", destination.pod)
+
+	putString(record, "requestId", request.GetRequestId())
+	headers := request.GetRequestHeaders()
+	putString(record, "traceId", headers[traceIdHeader])
+	putString(record, "spanId", headers[spanIdHeader])
+	putString(record, "parentSpanId", headers[parentSpanIdHeader])
+	putString(record, "requestPath", request.GetPath())
+	if method := request.GetRequestMethod(); method != core.RequestMethod_METHOD_UNSPECIFIED {
+		record["requestMethod"] = method.String()
+	}
+	if request != nil {
+		record["requestSizeBytes"] = int64(request.RequestBodyBytes)
+	}
+	if response != nil {
+		record["responseSizeBytes"] = int64(response.ResponseBodyBytes)
+		if response.ResponseCode != nil {
+			record["responseCode"] = int64(response.ResponseCode.Value)
+		}
+	}
+	if duration, err := ptypes.Duration(common.GetTimeToLastDownstreamTxByte()); err == nil && duration > 0 {
+		record["responseDurationNanoSec"] = int64(duration / time.Nanosecond)
+	}
+	return record
+}
+
+// upstreamPeer returns the kind of the reporter and the peer workload using the upstream cluster, which Istio names
+// as inbound|<port>|<subset>|<host> or outbound|<port>|<subset>|<host>
+func upstreamPeer(cluster string) (string, *workload) {
+	parts := strings.Split(cluster, "|")
+	if len(parts) != 4 || (parts[0] != inboundReporter && parts[0] != outboundReporter) {
+		return "", &workload{}
+	}
+	peer := &workload{}
+	if parts[0] == outboundReporter {
+		// The host of the outbound clusters is the service, such as hr--hr-service.default.svc.cluster.local
+		hostParts := strings.Split(parts[3], ".")
+		if len(hostParts) > 1 {
+			peer.namespace = hostParts[1]
+		}
+	}
+	return parts[0], peer
+}
+
+// headerWorkload returns the peer workload using the metadata exchanged by the Istio proxies, keeping the attributes
+// of the given workload which are not known from the headers
+func headerWorkload(headers map[string]string, peer *workload) *workload {
+	id := headers[peerMetadataIdHeader]
+	var fields map[string]*_struct.Value
+	if encoded := headers[peerMetadataHeader]; encoded != "" {
+		metadata := &_struct.Struct{}
+		if bytesArr, err := base64.StdEncoding.DecodeString(encoded); err == nil &&
+			proto.Unmarshal(bytesArr, metadata) == nil {
+			fields = metadata.GetFields()
+		}
+	}
+	if id == "" && fields == nil {
+		return peer
+	}
+	resolved := toWorkload(id, fields)
+	if resolved.namespace == "" {
+		resolved.namespace = peer.namespace
+	}
+	return resolved
+}
+
+// socketAddress returns the IP address of the socket, or an empty string if it is not known
+func socketAddress(address *core.Address) string {
+	return address.GetSocketAddress().GetAddress()
+}
+
+// nodeWorkload returns the workload of the Envoy node
+func nodeWorkload(node *core.Node) *workload {
+	return toWorkload(node.GetId(), node.GetMetadata().GetFields())
+}
+
+// toWorkload returns the workload of a proxy using the metadata added by Istio, falling back to the ID of the
+// proxy, which Istio sets as sidecar~<ip>~<pod>.<namespace>~<namespace>.svc.cluster.local
+func toWorkload(id string, fields map[string]*_struct.Value) *workload {
+	proxy := &workload{}
+	parts := strings.Split(id, "~")
+	if len(parts) == 4 {
+		if i := strings.LastIndex(parts[2], "."); i > 0 {
+			proxy.pod = parts[2][:i]
+			proxy.namespace = parts[2][i+1:]
+		}
+	}
+	if name := fields["NAME"].GetStringValue(); name != "" {
+		proxy.pod = name
+	}
+	if namespace := fields["NAMESPACE"].GetStringValue(); namespace != "" {
+		proxy.namespace = namespace
+	}
+	labels := fields["LABELS"].GetStructValue().GetFields()
+	if cell := labels[cellLabel].GetStringValue(); cell != "" {
+		proxy.instance = cell
+		proxy.instanceKind = cellKind
+	} else if composite := labels[compositeLabel].GetStringValue(); composite != "" {
+		proxy.instance = composite
+		proxy.instanceKind = compositeKind
+	}
+	proxy.component = labels[componentLabel].GetStringValue()
+	return proxy
+}
+
+func putString(record map[string]interface{}, key string, value string) {
+	if value != "" {
+		record[key] = value
+	}
+}
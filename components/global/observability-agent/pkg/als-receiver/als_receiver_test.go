@@ -0,0 +1,327 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package als_receiver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	core "github.com/envoyproxy/go-control-plane/envoy/api/v2/core"
+	accesslog "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v2"
+	als "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v2"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"google.golang.org/grpc"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+)
+
+func stringValue(value string) *_struct.Value {
+	return &_struct.Value{Kind: &_struct.Value_StringValue{StringValue: value}}
+}
+
+func sampleNode() *core.Node {
+	return &core.Node{
+		Id: "sidecar~10.1.1.5~hr--hr-deployment-7d8f9-x2k4q.default~default.svc.cluster.local",
+		Metadata: &_struct.Struct{Fields: map[string]*_struct.Value{
+			"LABELS": {Kind: &_struct.Value_StructValue{StructValue: &_struct.Struct{
+				Fields: map[string]*_struct.Value{
+					cellLabel:      stringValue("hr"),
+					componentLabel: stringValue("hr"),
+				},
+			}}},
+		}},
+	}
+}
+
+func sampleAddress(ip string) *core.Address {
+	return &core.Address{Address: &core.Address_SocketAddress{SocketAddress: &core.SocketAddress{Address: ip}}}
+}
+
+// peerMetadata encodes the metadata of a proxy as the Istio metadata exchange does
+func peerMetadata(t *testing.T, pod string, namespace string, cell string, component string) string {
+	metadata := &_struct.Struct{Fields: map[string]*_struct.Value{
+		"NAME":      stringValue(pod),
+		"NAMESPACE": stringValue(namespace),
+		"LABELS": {Kind: &_struct.Value_StructValue{StructValue: &_struct.Struct{
+			Fields: map[string]*_struct.Value{
+				cellLabel:      stringValue(cell),
+				componentLabel: stringValue(component),
+			},
+		}}},
+	}}
+	bytesArr, err := proto.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("Could not marshal the peer metadata : %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(bytesArr)
+}
+
+func sampleEntry(cluster string) *accesslog.HTTPAccessLogEntry {
+	return &accesslog.HTTPAccessLogEntry{
+		CommonProperties: &accesslog.AccessLogCommon{
+			UpstreamCluster:            cluster,
+			TimeToLastDownstreamTxByte: ptypes.DurationProto(25 * time.Millisecond),
+			DownstreamRemoteAddress:    sampleAddress("10.1.1.9"),
+			UpstreamRemoteAddress:      sampleAddress("10.1.1.7"),
+		},
+		Request: &accesslog.HTTPRequestProperties{
+			RequestMethod:    core.RequestMethod_GET,
+			Path:             "/employees",
+			RequestId:        "7b1b3f1c-5d1a-4c5e-9a57-1d3b6c0e2f4a",
+			RequestBodyBytes: 128,
+			RequestHeaders: map[string]string{
+				traceIdHeader: "463ac35c9f6413ad",
+				spanIdHeader:  "72485a3953bb6124",
+			},
+		},
+		Response: &accesslog.HTTPResponseProperties{
+			ResponseCode:      &wrappers.UInt32Value{Value: 200},
+			ResponseBodyBytes: 2048,
+			ResponseHeaders:   map[string]string{},
+		},
+	}
+}
+
+func TestToRecord(t *testing.T) {
+	reporter := nodeWorkload(sampleNode())
+	tests := []struct {
+		cluster         string
+		requestHeaders  map[string]string
+		responseHeaders map[string]string
+		expected        map[string]interface{}
+	}{
+		{
+			cluster: "outbound|80||stock--stock-service.stock.svc.cluster.local",
+			responseHeaders: map[string]string{
+				peerMetadataHeader: peerMetadata(t, "stock--stock-deployment-6b7c8-p9z3m", "stock", "stock",
+					"stock"),
+			},
+			expected: map[string]interface{}{
+				"contextReporterKind":     "outbound",
+				"sourceNamespace":         "default",
+				"sourceInstance":          "hr",
+				"sourceInstanceKind":      "Cell",
+				"sourceComponent":         "hr",
+				"sourcePod":               "hr--hr-deployment-7d8f9-x2k4q",
+				"destinationNamespace":    "stock",
+				"destinationInstance":     "stock",
+				"destinationInstanceKind": "Cell",
+				"destinationComponent":    "stock",
+				"destinationPod":          "stock--stock-deployment-6b7c8-p9z3m",
+				"requestId":               "7b1b3f1c-5d1a-4c5e-9a57-1d3b6c0e2f4a",
+				"traceId":                 "463ac35c9f6413ad",
+				"spanId":                  "72485a3953bb6124",
+				"requestPath":             "/employees",
+				"requestMethod":           "GET",
+				"requestSizeBytes":        int64(128),
+				"responseCode":            int64(200),
+				"responseSizeBytes":       int64(2048),
+				"responseDurationNanoSec": int64(25000000),
+			},
+		},
+		{
+			cluster: "inbound|8080|http|hr--hr-service.default.svc.cluster.local",
+			requestHeaders: map[string]string{
+				peerMetadataIdHeader: "sidecar~10.1.1.9~gateway--gateway-deployment-5f6d7-k2j8n.default~" +
+					"default.svc.cluster.local",
+			},
+			expected: map[string]interface{}{
+				"contextReporterKind":     "inbound",
+				"sourceNamespace":         "default",
+				"sourcePod":               "gateway--gateway-deployment-5f6d7-k2j8n",
+				"destinationNamespace":    "default",
+				"destinationInstance":     "hr",
+				"destinationInstanceKind": "Cell",
+				"destinationComponent":    "hr",
+				"destinationPod":          "hr--hr-deployment-7d8f9-x2k4q",
+				"requestId":               "7b1b3f1c-5d1a-4c5e-9a57-1d3b6c0e2f4a",
+				"traceId":                 "463ac35c9f6413ad",
+				"spanId":                  "72485a3953bb6124",
+				"requestPath":             "/employees",
+				"requestMethod":           "GET",
+				"requestSizeBytes":        int64(128),
+				"responseCode":            int64(200),
+				"responseSizeBytes":       int64(2048),
+				"responseDurationNanoSec": int64(25000000),
+			},
+		},
+		{
+			// The address of the peer is used when the proxies do not exchange their metadata
+			cluster: "outbound|80||stock--stock-service.stock.svc.cluster.local",
+			expected: map[string]interface{}{
+				"contextReporterKind":     "outbound",
+				"sourceNamespace":         "default",
+				"sourceInstance":          "hr",
+				"sourceInstanceKind":      "Cell",
+				"sourceComponent":         "hr",
+				"sourcePod":               "hr--hr-deployment-7d8f9-x2k4q",
+				"destinationNamespace":    "stock",
+				"destinationPod":          "10.1.1.7",
+				"requestId":               "7b1b3f1c-5d1a-4c5e-9a57-1d3b6c0e2f4a",
+				"traceId":                 "463ac35c9f6413ad",
+				"spanId":                  "72485a3953bb6124",
+				"requestPath":             "/employees",
+				"requestMethod":           "GET",
+				"requestSizeBytes":        int64(128),
+				"responseCode":            int64(200),
+				"responseSizeBytes":       int64(2048),
+				"responseDurationNanoSec": int64(25000000),
+			},
+		},
+	}
+	for _, test := range tests {
+		entry := sampleEntry(test.cluster)
+		for key, value := range test.requestHeaders {
+			entry.Request.RequestHeaders[key] = value
+		}
+		for key, value := range test.responseHeaders {
+			entry.Response.ResponseHeaders[key] = value
+		}
+		record := toRecord(reporter, entry)
+		if !reflect.DeepEqual(record, test.expected) {
+			t.Errorf("Expected the record %v for the cluster %s, but received %v", test.expected, test.cluster,
+				record)
+		}
+	}
+}
+
+func TestNodeWorkloadWithMetadata(t *testing.T) {
+	node := sampleNode()
+	node.Metadata.Fields["NAME"] = stringValue("pet-fe--portal-deployment-5c9d-q8w2e")
+	node.Metadata.Fields["NAMESPACE"] = stringValue("pets")
+	node.Metadata.Fields["LABELS"] = &_struct.Value{Kind: &_struct.Value_StructValue{StructValue: &_struct.Struct{
+		Fields: map[string]*_struct.Value{
+			compositeLabel: stringValue("pet-fe"),
+			componentLabel: stringValue("portal"),
+		},
+	}}}
+	reporter := nodeWorkload(node)
+	expected := &workload{
+		namespace:    "pets",
+		instance:     "pet-fe",
+		instanceKind: "Composite",
+		component:    "portal",
+		pod:          "pet-fe--portal-deployment-5c9d-q8w2e",
+	}
+	if !reflect.DeepEqual(reporter, expected) {
+		t.Errorf("Expected the workload %+v, but received %+v", expected, reporter)
+	}
+}
+
+func TestStreamAccessLogs(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	redactor, err := redaction.New(&redaction.Redaction{
+		Rules: []*redaction.Rule{{Keys: []string{"requestPath"}, QueryParameters: []string{"token"}}},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the redactor : %v", err)
+	}
+	buffer := make(chan string, 100)
+	receiver, err := New(&AccessLogService{}, logger, buffer, nil, redactor)
+	if err != nil {
+		t.Fatalf("Could not create the receiver : %v", err)
+	}
+	errCh := make(chan error, 1)
+	go receiver.Run(errCh)
+	defer func() { _ = receiver.Close() }()
+
+	conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", DefaultPort), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Could not connect to the receiver : %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	stream, err := als.NewAccessLogServiceClient(conn).StreamAccessLogs(context.Background())
+	if err != nil {
+		t.Fatalf("Could not open the stream : %v", err)
+	}
+	outboundEntry := sampleEntry("outbound|80||stock--stock-service.stock.svc.cluster.local")
+	inboundEntry := sampleEntry("inbound|8080|http|hr--hr-service.default.svc.cluster.local")
+	outboundEntry.Request.Path = "/employees?token=abc"
+	inboundEntry.Request.Path = "/employees?token=abc"
+	messages := []*als.StreamAccessLogsMessage{
+		{
+			Identifier: &als.StreamAccessLogsMessage_Identifier{Node: sampleNode(), LogName: "cellery"},
+			LogEntries: &als.StreamAccessLogsMessage_HttpLogs{HttpLogs: &als.StreamAccessLogsMessage_HTTPAccessLogEntries{
+				LogEntry: []*accesslog.HTTPAccessLogEntry{outboundEntry},
+			}},
+		},
+		{
+			LogEntries: &als.StreamAccessLogsMessage_TcpLogs{TcpLogs: &als.StreamAccessLogsMessage_TCPAccessLogEntries{
+				LogEntry: []*accesslog.TCPAccessLogEntry{{}},
+			}},
+		},
+		{
+			LogEntries: &als.StreamAccessLogsMessage_HttpLogs{HttpLogs: &als.StreamAccessLogsMessage_HTTPAccessLogEntries{
+				LogEntry: []*accesslog.HTTPAccessLogEntry{inboundEntry},
+			}},
+		},
+	}
+	for _, message := range messages {
+		err = stream.Send(message)
+		if err != nil {
+			t.Fatalf("Could not send the access logs : %v", err)
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Could not close the stream : %v", err)
+	}
+
+	expectedReporterKinds := []string{"outbound", "inbound"}
+	if len(buffer) != len(expectedReporterKinds) {
+		t.Fatalf("Expected %d records, but received %d", len(expectedReporterKinds), len(buffer))
+	}
+	for _, expectedReporterKind := range expectedReporterKinds {
+		record := make(map[string]interface{})
+		err = json.Unmarshal([]byte(<-buffer), &record)
+		if err != nil {
+			t.Errorf("Could not unmarshal the record : %v", err)
+			continue
+		}
+		if record["requestPath"] != "/employees?token=[REDACTED]" {
+			t.Errorf("Expected the request path to be redacted, but received %v", record["requestPath"])
+		}
+		if record["contextReporterKind"] != expectedReporterKind {
+			t.Errorf("Expected the reporter kind %s, but received %v", expectedReporterKind,
+				record["contextReporterKind"])
+		}
+		// The identifier of the first message is used for the rest of the stream, and the peer is known from its
+		// address, so that the record is not dropped for lacking either of the pods
+		expectedSourcePod, expectedDestinationPod := "hr--hr-deployment-7d8f9-x2k4q", "10.1.1.7"
+		if expectedReporterKind == inboundReporter {
+			expectedSourcePod, expectedDestinationPod = "10.1.1.9", "hr--hr-deployment-7d8f9-x2k4q"
+		}
+		if record["sourcePod"] != expectedSourcePod || record["destinationPod"] != expectedDestinationPod {
+			t.Errorf("Expected the source pod %s and the destination pod %s in the record, but received %v",
+				expectedSourcePod, expectedDestinationPod, record)
+		}
+	}
+}
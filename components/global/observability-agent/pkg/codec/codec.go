@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	JSON        = "json"
+	NDJSON      = "ndjson"
+	Protobuf    = "protobuf"
+	MessagePack = "msgpack"
+)
+
+const (
+	// TelemetrySchema is used for the records produced by the telemetry agent
+	TelemetrySchema Schema = iota
+	// SpanSchema is used for the spans produced by the tracing agent
+	SpanSchema
+)
+
+type (
+	// Codec converts the JSON arrays written by the writer into the format sent over the wire
+	Codec interface {
+		ContentType() string
+		Encode(w io.Writer, jsonArr string) error
+	}
+
+	// Schema is the type of the records in the batches, which decides the Protobuf messages used
+	Schema int
+
+	jsonCodec struct{}
+)
+
+// New returns the codec with the given name. JSON is used if the name is empty.
+func New(name string, schema Schema) (Codec, error) {
+	switch name {
+	case "", JSON:
+		return &jsonCodec{}, nil
+	case NDJSON:
+		return &ndjsonCodec{}, nil
+	case Protobuf:
+		return &protobufCodec{schema: schema}, nil
+	case MessagePack:
+		return &msgpackCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown encoding %s", name)
+	}
+}
+
+func (codec *jsonCodec) ContentType() string {
+	return "application/json"
+}
+
+func (codec *jsonCodec) Encode(w io.Writer, jsonArr string) error {
+	_, err := io.WriteString(w, jsonArr)
+	return err
+}
+
+// decodeRecords decodes the elements of a JSON array keeping the numbers as json.Number
+func decodeRecords(jsonArr string) ([]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewBufferString(jsonArr))
+	decoder.UseNumber()
+	var records []interface{}
+	err := decoder.Decode(&records)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the JSON array : %v", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package codec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec/telemetry"
+)
+
+var (
+	testStr = "[{\"requestPath\":\"/hello\",\"responseCode\":200,\"duration\":1.5,\"secure\":true}," +
+		"{\"requestPath\":\"/\",\"responseCode\":404}]"
+	testSpanStr = "[{\"traceId\":\"b55a0f7f20d36e49\",\"id\":\"ae295f3a4bbbe537\",\"operationName\":\"get\"," +
+		"\"serviceName\":\"pet-be\",\"spanKind\":\"SERVER\",\"timestamp\":1559000000000,\"duration\":20," +
+		"\"tags\":\"{}\"}]"
+)
+
+func TestNewWithUnknownEncoding(t *testing.T) {
+	_, err := New("xml", TelemetrySchema)
+	expectedErr := "unknown encoding xml"
+	if err == nil {
+		t.Errorf("An error was not thrown, but expected : %s", expectedErr)
+		return
+	}
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+}
+
+func TestContentTypes(t *testing.T) {
+	tests := map[string]string{
+		"":          "application/json",
+		JSON:        "application/json",
+		NDJSON:      "application/x-ndjson",
+		Protobuf:    "application/x-protobuf; messageType=cellery.observability.agent.TelemetryBatch",
+		MessagePack: "application/x-msgpack",
+	}
+	for name, expected := range tests {
+		codec, err := New(name, TelemetrySchema)
+		if err != nil {
+			t.Errorf("Unexpected error occurred : %v", err)
+			continue
+		}
+		if codec.ContentType() != expected {
+			t.Errorf("Unexpected content type for %s, expected : %s, received : %s", name, expected,
+				codec.ContentType())
+		}
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	codec, _ := New(JSON, TelemetrySchema)
+	var buf bytes.Buffer
+	err := codec.Encode(&buf, testStr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if buf.String() != testStr {
+		t.Errorf("Unexpected output, expected : %s, received : %s", testStr, buf.String())
+	}
+}
+
+func TestEncodeNDJSON(t *testing.T) {
+	codec, _ := New(NDJSON, TelemetrySchema)
+	var buf bytes.Buffer
+	err := codec.Encode(&buf, "[{\"a\": 1},\n {\"b\": \"c\"}]")
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	expected := "{\"a\":1}\n{\"b\":\"c\"}\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected output, expected : %s, received : %s", expected, buf.String())
+	}
+	err = codec.Encode(&buf, "{")
+	if err == nil {
+		t.Error("An error was not thrown for an invalid JSON array")
+	}
+}
+
+func TestEncodeMessagePack(t *testing.T) {
+	codec, _ := New(MessagePack, TelemetrySchema)
+	var buf bytes.Buffer
+	err := codec.Encode(&buf, "[{\"code\":200,\"ok\":true,\"path\":\"/\",\"size\":-1,\"time\":1.5,\"user\":null}]")
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	expected := "91" + "86" +
+		"a4636f6465" + "d300000000000000c8" +
+		"a26f6b" + "c3" +
+		"a470617468" + "a12f" +
+		"a473697a65" + "ff" +
+		"a474696d65" + "cb3ff8000000000000" +
+		"a475736572" + "c0"
+	if hex.EncodeToString(buf.Bytes()) != expected {
+		t.Errorf("Unexpected output, expected : %s, received : %s", expected, hex.EncodeToString(buf.Bytes()))
+	}
+}
+
+func TestEncodeTelemetryProtobuf(t *testing.T) {
+	codec, _ := New(Protobuf, TelemetrySchema)
+	var buf bytes.Buffer
+	err := codec.Encode(&buf, testStr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	batch := &telemetry.TelemetryBatch{}
+	err = proto.Unmarshal(buf.Bytes(), batch)
+	if err != nil {
+		t.Errorf("Error when unmarshalling the batch : %v", err)
+		return
+	}
+	if len(batch.Records) != 2 {
+		t.Errorf("Expected 2 records, but received : %d", len(batch.Records))
+		return
+	}
+	attributes := batch.Records[0].Attributes
+	if len(attributes) != 4 {
+		t.Errorf("Expected 4 attributes, but received : %v", attributes)
+	}
+	if attributes["requestPath"].GetStringValue() != "/hello" {
+		t.Errorf("Unexpected request path : %v", attributes["requestPath"])
+	}
+	if attributes["responseCode"].GetIntValue() != 200 {
+		t.Errorf("Unexpected response code : %v", attributes["responseCode"])
+	}
+	if attributes["duration"].GetDoubleValue() != 1.5 {
+		t.Errorf("Unexpected duration : %v", attributes["duration"])
+	}
+	if !attributes["secure"].GetBoolValue() {
+		t.Errorf("Unexpected secure flag : %v", attributes["secure"])
+	}
+}
+
+func TestEncodeSpanProtobuf(t *testing.T) {
+	codec, _ := New(Protobuf, SpanSchema)
+	var buf bytes.Buffer
+	err := codec.Encode(&buf, testSpanStr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	batch := &telemetry.SpanBatch{}
+	err = proto.Unmarshal(buf.Bytes(), batch)
+	if err != nil {
+		t.Errorf("Error when unmarshalling the batch : %v", err)
+		return
+	}
+	expected := []*telemetry.Span{{
+		TraceId:       "b55a0f7f20d36e49",
+		Id:            "ae295f3a4bbbe537",
+		OperationName: "get",
+		ServiceName:   "pet-be",
+		SpanKind:      "SERVER",
+		Timestamp:     1559000000000,
+		Duration:      20,
+		Tags:          "{}",
+	}}
+	if !reflect.DeepEqual(batch.Spans, expected) {
+		t.Errorf("Unexpected spans, expected : %v, received : %v", expected, batch.Spans)
+	}
+}
+
+func TestStreamEncoders(t *testing.T) {
+	records := []string{"{\"requestPath\":\"/hello\",\"responseCode\":200,\"duration\":1.5,\"secure\":true}",
+		"{\"requestPath\":\"/\",\"responseCode\":404}"}
+	for _, name := range []string{JSON, NDJSON, Protobuf} {
+		codec, _ := New(name, TelemetrySchema)
+		streamingCodec, ok := codec.(StreamingCodec)
+		if !ok {
+			t.Errorf("Expected %s to support streaming", name)
+			continue
+		}
+		var expected bytes.Buffer
+		_ = codec.Encode(&expected, testStr)
+		var buf bytes.Buffer
+		encoder := streamingCodec.NewStreamEncoder()
+		err := encoder.Begin(&buf)
+		for _, record := range records {
+			if err == nil {
+				err = encoder.EncodeRecord(&buf, []byte(record))
+			}
+		}
+		if err == nil {
+			err = encoder.End(&buf)
+		}
+		if err != nil {
+			t.Errorf("Unexpected error occurred : %v", err)
+		}
+		// The streamed batch is expected to be identical to the batch encoded at once
+		if !bytes.Equal(buf.Bytes(), expected.Bytes()) {
+			t.Errorf("Unexpected output for %s, expected : %q, received : %q", name, expected.String(),
+				buf.String())
+		}
+	}
+	codec, _ := New(MessagePack, TelemetrySchema)
+	if _, ok := codec.(StreamingCodec); ok {
+		t.Error("Expected MessagePack not to support streaming, since the array length comes first")
+	}
+}
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package codec
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+type (
+	// msgpackCodec writes the batch as a MessagePack array of maps
+	msgpackCodec struct{}
+
+	msgpackWriter struct {
+		*bufio.Writer
+		scratch [9]byte
+	}
+)
+
+func (codec *msgpackCodec) ContentType() string {
+	return "application/x-msgpack"
+}
+
+func (codec *msgpackCodec) Encode(w io.Writer, jsonArr string) error {
+	records, err := decodeRecords(jsonArr)
+	if err != nil {
+		return err
+	}
+	writer := &msgpackWriter{Writer: bufio.NewWriter(w)}
+	err = writer.writeValue(records)
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func (writer *msgpackWriter) writeValue(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return writer.WriteByte(0xc0)
+	case bool:
+		if v {
+			return writer.WriteByte(0xc3)
+		}
+		return writer.WriteByte(0xc2)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return writer.writeInt(i)
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("could not convert %s to a number : %v", v, err)
+		}
+		return writer.writeFloat(f)
+	case float64:
+		return writer.writeFloat(v)
+	case int64:
+		return writer.writeInt(v)
+	case string:
+		return writer.writeString(v)
+	case []interface{}:
+		err := writer.writeHeader(len(v), 0x90, 0xdc, 0xdd)
+		if err != nil {
+			return err
+		}
+		for _, element := range v {
+			if err = writer.writeValue(element); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[string]interface{}:
+		err := writer.writeHeader(len(v), 0x80, 0xde, 0xdf)
+		if err != nil {
+			return err
+		}
+		// Keys are sorted to keep the output stable
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if err = writer.writeString(key); err != nil {
+				return err
+			}
+			if err = writer.writeValue(v[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %T", value)
+	}
+}
+
+// writeHeader writes the header of an array or a map, using the fix format for less than 16 elements
+func (writer *msgpackWriter) writeHeader(length int, fixPrefix, prefix16, prefix32 byte) error {
+	switch {
+	case length < 16:
+		return writer.WriteByte(fixPrefix | byte(length))
+	case length <= math.MaxUint16:
+		return writer.writeUint16(prefix16, uint16(length))
+	default:
+		return writer.writeUint32(prefix32, uint32(length))
+	}
+}
+
+func (writer *msgpackWriter) writeString(str string) error {
+	length := len(str)
+	var err error
+	switch {
+	case length < 32:
+		err = writer.WriteByte(0xa0 | byte(length))
+	case length <= math.MaxUint8:
+		writer.scratch[0] = 0xd9
+		writer.scratch[1] = byte(length)
+		_, err = writer.Write(writer.scratch[:2])
+	case length <= math.MaxUint16:
+		err = writer.writeUint16(0xda, uint16(length))
+	default:
+		err = writer.writeUint32(0xdb, uint32(length))
+	}
+	if err != nil {
+		return err
+	}
+	_, err = writer.WriteString(str)
+	return err
+}
+
+func (writer *msgpackWriter) writeInt(i int64) error {
+	switch {
+	case i >= 0 && i <= 127:
+		return writer.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		return writer.WriteByte(byte(int8(i)))
+	default:
+		writer.scratch[0] = 0xd3
+		binary.BigEndian.PutUint64(writer.scratch[1:], uint64(i))
+		_, err := writer.Write(writer.scratch[:9])
+		return err
+	}
+}
+
+func (writer *msgpackWriter) writeFloat(f float64) error {
+	writer.scratch[0] = 0xcb
+	binary.BigEndian.PutUint64(writer.scratch[1:], math.Float64bits(f))
+	_, err := writer.Write(writer.scratch[:9])
+	return err
+}
+
+func (writer *msgpackWriter) writeUint16(prefix byte, value uint16) error {
+	writer.scratch[0] = prefix
+	binary.BigEndian.PutUint16(writer.scratch[1:], value)
+	_, err := writer.Write(writer.scratch[:3])
+	return err
+}
+
+func (writer *msgpackWriter) writeUint32(prefix byte, value uint32) error {
+	writer.scratch[0] = prefix
+	binary.BigEndian.PutUint32(writer.scratch[1:], value)
+	_, err := writer.Write(writer.scratch[:5])
+	return err
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+type (
+	// ndjsonCodec writes each element of the batch as a separate line, so that the receiver can parse the
+	// batch as a stream
+	ndjsonCodec struct{}
+)
+
+func (codec *ndjsonCodec) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (codec *ndjsonCodec) Encode(w io.Writer, jsonArr string) error {
+	var elements []json.RawMessage
+	err := json.Unmarshal([]byte(jsonArr), &elements)
+	if err != nil {
+		return fmt.Errorf("could not unmarshal the JSON array : %v", err)
+	}
+	var buf bytes.Buffer
+	for _, element := range elements {
+		buf.Reset()
+		err = json.Compact(&buf, element)
+		if err != nil {
+			return fmt.Errorf("could not compact the element : %v", err)
+		}
+		buf.WriteByte('\n')
+		if _, err = w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
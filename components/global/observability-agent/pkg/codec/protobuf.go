@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// nolint:lll
+// Generates the Go types of the messages in telemetry.proto.
+//go:generate protoc -I. -I$GOPATH/src/github.com/gogo/protobuf -I$GOPATH/src/github.com/gogo/protobuf/protobuf --gogoslick_out=. telemetry/telemetry.proto
+
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec/telemetry"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
+)
+
+const (
+	protobufPackage = "cellery.observability.agent"
+)
+
+type (
+	// protobufCodec writes the batch using the messages defined in telemetry.proto
+	protobufCodec struct {
+		schema Schema
+	}
+)
+
+func (codec *protobufCodec) ContentType() string {
+	if codec.schema == SpanSchema {
+		return "application/x-protobuf; messageType=" + protobufPackage + ".SpanBatch"
+	}
+	return "application/x-protobuf; messageType=" + protobufPackage + ".TelemetryBatch"
+}
+
+func (codec *protobufCodec) Encode(w io.Writer, jsonArr string) error {
+	var batch proto.Message
+	var err error
+	if codec.schema == SpanSchema {
+		batch, err = toSpanBatch(jsonArr)
+	} else {
+		batch, err = toTelemetryBatch(jsonArr)
+	}
+	if err != nil {
+		return err
+	}
+	bytesArr, err := proto.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("could not marshal the batch : %v", err)
+	}
+	_, err = w.Write(bytesArr)
+	return err
+}
+
+func toTelemetryBatch(jsonArr string) (*telemetry.TelemetryBatch, error) {
+	records, err := decodeRecords(jsonArr)
+	if err != nil {
+		return nil, err
+	}
+	batch := &telemetry.TelemetryBatch{}
+	for _, record := range records {
+		attributes, ok := record.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected a JSON object, but received %T", record)
+		}
+		message := &telemetry.Telemetry{Attributes: make(map[string]*telemetry.Value, len(attributes))}
+		for key, attribute := range attributes {
+			value, err := toValue(attribute)
+			if err != nil {
+				return nil, fmt.Errorf("could not encode the attribute %s : %v", key, err)
+			}
+			message.Attributes[key] = value
+		}
+		batch.Records = append(batch.Records, message)
+	}
+	return batch, nil
+}
+
+func toValue(in interface{}) (*telemetry.Value, error) {
+	value := &telemetry.Value{}
+	switch v := in.(type) {
+	case string:
+		value.Kind = &telemetry.Value_StringValue{StringValue: v}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			value.Kind = &telemetry.Value_IntValue{IntValue: i}
+		} else {
+			f, err := v.Float64()
+			if err != nil {
+				return nil, err
+			}
+			value.Kind = &telemetry.Value_DoubleValue{DoubleValue: f}
+		}
+	case bool:
+		value.Kind = &telemetry.Value_BoolValue{BoolValue: v}
+	case nil:
+	default:
+		str, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		value.Kind = &telemetry.Value_StringValue{StringValue: string(str)}
+	}
+	return value, nil
+}
+
+func toSpanBatch(jsonArr string) (*telemetry.SpanBatch, error) {
+	var spans []tracing.ProcessedSpan
+	err := json.Unmarshal([]byte(jsonArr), &spans)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal the spans : %v", err)
+	}
+	batch := &telemetry.SpanBatch{}
+	for _, span := range spans {
+		batch.Spans = append(batch.Spans, &telemetry.Span{
+			TraceId:       span.TraceID,
+			ParentId:      span.ParentID,
+			Id:            span.ID,
+			OperationName: span.Name,
+			ServiceName:   span.ServiceName,
+			SpanKind:      span.SpanKind,
+			Timestamp:     span.Timestamp,
+			Duration:      span.Duration,
+			Tags:          span.Tags,
+		})
+	}
+	return batch, nil
+}
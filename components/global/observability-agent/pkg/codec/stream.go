@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package codec
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type (
+	// StreamEncoder encodes the records of a batch one at a time, so that the whole encoded batch does not have
+	// to be held in memory
+	StreamEncoder interface {
+		Begin(w io.Writer) error
+		EncodeRecord(w io.Writer, record json.RawMessage) error
+		End(w io.Writer) error
+	}
+
+	// StreamingCodec is implemented by the codecs which are able to encode a batch as a stream of records
+	StreamingCodec interface {
+		Codec
+		NewStreamEncoder() StreamEncoder
+	}
+
+	jsonStreamEncoder struct {
+		records int
+	}
+
+	// recordStreamEncoder encodes each record as a batch of its own. This works for the formats in which a
+	// sequence of encoded batches reads as a single batch, such as NDJSON and Protobuf, where the repeated fields
+	// of concatenated messages are merged.
+	recordStreamEncoder struct {
+		codec Codec
+	}
+)
+
+func (codec *jsonCodec) NewStreamEncoder() StreamEncoder {
+	return &jsonStreamEncoder{}
+}
+
+func (codec *ndjsonCodec) NewStreamEncoder() StreamEncoder {
+	return &recordStreamEncoder{codec: codec}
+}
+
+func (codec *protobufCodec) NewStreamEncoder() StreamEncoder {
+	return &recordStreamEncoder{codec: codec}
+}
+
+func (encoder *jsonStreamEncoder) Begin(w io.Writer) error {
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (encoder *jsonStreamEncoder) EncodeRecord(w io.Writer, record json.RawMessage) error {
+	if encoder.records > 0 {
+		if _, err := io.WriteString(w, ","); err != nil {
+			return err
+		}
+	}
+	encoder.records++
+	_, err := w.Write(record)
+	return err
+}
+
+func (encoder *jsonStreamEncoder) End(w io.Writer) error {
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+func (encoder *recordStreamEncoder) Begin(w io.Writer) error {
+	return nil
+}
+
+func (encoder *recordStreamEncoder) EncodeRecord(w io.Writer, record json.RawMessage) error {
+	return encoder.codec.Encode(w, "["+string(record)+"]")
+}
+
+func (encoder *recordStreamEncoder) End(w io.Writer) error {
+	return nil
+}
@@ -28,22 +28,54 @@ import (
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store/memory"
 
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/adapter"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/aggregator"
+	als_receiver "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/als-receiver"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/publisher"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+	scrape_receiver "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/scrape-receiver"
 )
 
 type (
 	Config struct {
-		adapter.Mixer        `json:"mixer"`
+		adapter.Mixer `json:"mixer"`
+		// AccessLogService enables receiving the telemetry from the Envoy proxies, for the meshes without Mixer
+		AccessLogService *als_receiver.AccessLogService `json:"accessLogService"`
+		// PrometheusScrape enables scraping the request metrics of the Envoy proxies, for the meshes without Mixer
+		PrometheusScrape     *scrape_receiver.Scrape `json:"prometheusScrape"`
 		publisher.SpEndpoint `json:"spEndpoint"`
-		Store                struct {
+		// LogEntrySpEndpoint and TraceSpanSpEndpoint enable the logentry and tracespan templates of the adapter, whose
+		// records are published to SP streams of their own
+		LogEntrySpEndpoint  *publisher.SpEndpoint `json:"logEntrySpEndpoint"`
+		TraceSpanSpEndpoint *publisher.SpEndpoint `json:"traceSpanSpEndpoint"`
+		// Pipelines are the named SP streams, to which the Mixer handlers can route their records using the pipeline
+		// handler param
+		Pipelines map[string]*publisher.SpEndpoint `json:"pipelines"`
+		// Aggregation aggregates the records received from Mixer and Envoy into time windows before they are persisted
+		Aggregation *aggregator.Aggregation `json:"aggregation"`
+		// Redaction is applied to the received records before they are persisted
+		Redaction *redaction.Redaction `json:"redaction"`
+		Store     struct {
 			*file.File         `json:"fileStorage"`
 			*database.Database `json:"database"`
 			*memory.Memory     `json:"inMemory"`
 		} `json:"store"`
+		Exporters struct {
+			*publisher.Otlp                  `json:"otlp"`
+			*publisher.TracingBackend        `json:"tracingBackend"`
+			*publisher.PrometheusRemoteWrite `json:"prometheusRemoteWrite"`
+			*publisher.Elasticsearch         `json:"elasticsearch"`
+			*publisher.Kafka                 `json:"kafka"`
+			*publisher.Debug                 `json:"debug"`
+		} `json:"exporters"`
+		// DryRun runs the whole pipeline, but writes the batches using the debug exporter instead of sending them
+		DryRun   bool `json:"dryRun"`
 		Advanced struct {
 			MaxRecordsForSingleWrite int `json:"maxRecordsForSingleWrite"`
 			BufferSizeFactor         int `json:"bufferSizeFactor"`
 			BufferTimeoutSeconds     int `json:"bufferTimeoutSeconds"`
+			// Overflow is the policy for a full buffer
+			Overflow *overflow.Overflow `json:"overflow"`
 		} `json:"advanced"`
 	}
 )
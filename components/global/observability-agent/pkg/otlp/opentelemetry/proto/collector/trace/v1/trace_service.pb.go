@@ -0,0 +1,911 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: opentelemetry/proto/collector/trace/v1/trace_service.proto
+
+package v1
+
+import (
+	fmt "fmt"
+	v1 "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/trace/v1"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	reflect "reflect"
+	strings "strings"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type ExportTraceServiceRequest struct {
+	ResourceSpans []*v1.ResourceSpans `protobuf:"bytes,1,rep,name=resource_spans,json=resourceSpans,proto3" json:"resource_spans,omitempty"`
+}
+
+func (m *ExportTraceServiceRequest) Reset()      { *m = ExportTraceServiceRequest{} }
+func (*ExportTraceServiceRequest) ProtoMessage() {}
+func (*ExportTraceServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_192a962890318cf4, []int{0}
+}
+func (m *ExportTraceServiceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportTraceServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportTraceServiceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportTraceServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTraceServiceRequest.Merge(m, src)
+}
+func (m *ExportTraceServiceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportTraceServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTraceServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTraceServiceRequest proto.InternalMessageInfo
+
+func (m *ExportTraceServiceRequest) GetResourceSpans() []*v1.ResourceSpans {
+	if m != nil {
+		return m.ResourceSpans
+	}
+	return nil
+}
+
+type ExportTraceServiceResponse struct {
+	PartialSuccess *ExportTracePartialSuccess `protobuf:"bytes,1,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+}
+
+func (m *ExportTraceServiceResponse) Reset()      { *m = ExportTraceServiceResponse{} }
+func (*ExportTraceServiceResponse) ProtoMessage() {}
+func (*ExportTraceServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_192a962890318cf4, []int{1}
+}
+func (m *ExportTraceServiceResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportTraceServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportTraceServiceResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportTraceServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTraceServiceResponse.Merge(m, src)
+}
+func (m *ExportTraceServiceResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportTraceServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTraceServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTraceServiceResponse proto.InternalMessageInfo
+
+func (m *ExportTraceServiceResponse) GetPartialSuccess() *ExportTracePartialSuccess {
+	if m != nil {
+		return m.PartialSuccess
+	}
+	return nil
+}
+
+type ExportTracePartialSuccess struct {
+	RejectedSpans int64  `protobuf:"varint,1,opt,name=rejected_spans,json=rejectedSpans,proto3" json:"rejected_spans,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,2,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+}
+
+func (m *ExportTracePartialSuccess) Reset()      { *m = ExportTracePartialSuccess{} }
+func (*ExportTracePartialSuccess) ProtoMessage() {}
+func (*ExportTracePartialSuccess) Descriptor() ([]byte, []int) {
+	return fileDescriptor_192a962890318cf4, []int{2}
+}
+func (m *ExportTracePartialSuccess) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportTracePartialSuccess) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportTracePartialSuccess.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportTracePartialSuccess) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportTracePartialSuccess.Merge(m, src)
+}
+func (m *ExportTracePartialSuccess) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportTracePartialSuccess) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportTracePartialSuccess.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportTracePartialSuccess proto.InternalMessageInfo
+
+func (m *ExportTracePartialSuccess) GetRejectedSpans() int64 {
+	if m != nil {
+		return m.RejectedSpans
+	}
+	return 0
+}
+
+func (m *ExportTracePartialSuccess) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*ExportTraceServiceRequest)(nil), "opentelemetry.proto.collector.trace.v1.ExportTraceServiceRequest")
+	proto.RegisterType((*ExportTraceServiceResponse)(nil), "opentelemetry.proto.collector.trace.v1.ExportTraceServiceResponse")
+	proto.RegisterType((*ExportTracePartialSuccess)(nil), "opentelemetry.proto.collector.trace.v1.ExportTracePartialSuccess")
+}
+
+func init() {
+	proto.RegisterFile("opentelemetry/proto/collector/trace/v1/trace_service.proto", fileDescriptor_192a962890318cf4)
+}
+
+var fileDescriptor_192a962890318cf4 = []byte{
+	// 459 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x3f, 0x6f, 0xd3, 0x40,
+	0x14, 0xf7, 0xa5, 0x52, 0x25, 0xae, 0x4d, 0x11, 0x9e, 0xda, 0x0c, 0xa7, 0x2a, 0x88, 0xca, 0x08,
+	0xe5, 0x4e, 0x09, 0x1b, 0x1b, 0x41, 0x8c, 0x88, 0xc8, 0xa9, 0x18, 0x58, 0x22, 0xfb, 0x78, 0x72,
+	0x5d, 0xce, 0x7e, 0xc7, 0xdd, 0x25, 0x22, 0x0b, 0x62, 0x64, 0x84, 0x0f, 0xc0, 0xc2, 0x84, 0xf8,
+	0x24, 0x8c, 0x19, 0x3b, 0x12, 0x67, 0x61, 0xec, 0x47, 0x40, 0xb6, 0x69, 0x14, 0x4b, 0x41, 0x8a,
+	0xe8, 0xe6, 0xf7, 0xf3, 0xfb, 0xfd, 0xb3, 0xef, 0xe8, 0x13, 0xd4, 0x90, 0x3b, 0x50, 0x90, 0x81,
+	0x33, 0x73, 0xa1, 0x0d, 0x3a, 0x14, 0x12, 0x95, 0x02, 0xe9, 0xd0, 0x08, 0x67, 0x22, 0x09, 0x62,
+	0xd6, 0xaf, 0x1f, 0x26, 0x16, 0xcc, 0x2c, 0x95, 0xc0, 0xab, 0x35, 0xff, 0xac, 0xc1, 0xad, 0x41,
+	0xbe, 0xe6, 0xf2, 0x8a, 0xc2, 0x67, 0xfd, 0x4e, 0xb0, 0xcd, 0xa3, 0xa9, 0x5c, 0x93, 0xbb, 0x48,
+	0x4f, 0x9e, 0xbf, 0xd7, 0x68, 0xdc, 0x79, 0x09, 0x8e, 0x6b, 0xb7, 0x10, 0xde, 0x4d, 0xc1, 0x3a,
+	0x3f, 0xa4, 0x47, 0x06, 0x2c, 0x4e, 0x4d, 0x19, 0x44, 0x47, 0xb9, 0x3d, 0x26, 0xa7, 0x7b, 0xc1,
+	0xc1, 0xe0, 0x11, 0xdf, 0x96, 0xe3, 0xc6, 0x9d, 0x87, 0x7f, 0x39, 0xe3, 0x92, 0x12, 0xb6, 0xcd,
+	0xe6, 0xd8, 0xfd, 0x44, 0x68, 0x67, 0x9b, 0xa3, 0xd5, 0x98, 0x5b, 0xf0, 0x2f, 0xe9, 0x5d, 0x1d,
+	0x19, 0x97, 0x46, 0x6a, 0x62, 0xa7, 0x52, 0x82, 0x2d, 0x3d, 0x49, 0x70, 0x30, 0x78, 0xca, 0x77,
+	0xeb, 0xce, 0x37, 0xc4, 0x47, 0xb5, 0xd2, 0xb8, 0x16, 0x0a, 0x8f, 0x74, 0x63, 0xee, 0x26, 0xf4,
+	0xe4, 0x9f, 0xcb, 0xfe, 0x83, 0xb2, 0xfb, 0x25, 0x48, 0x07, 0x6f, 0xd6, 0xdd, 0x49, 0xb0, 0x17,
+	0xb6, 0x6f, 0xd0, 0xaa, 0x8e, 0x7f, 0x9f, 0xb6, 0xc1, 0x18, 0x34, 0x93, 0x0c, 0xac, 0x8d, 0x12,
+	0x38, 0x6e, 0x9d, 0x92, 0xe0, 0x4e, 0x78, 0x58, 0x81, 0x2f, 0x6a, 0x6c, 0xf0, 0x8d, 0xd0, 0xc3,
+	0xcd, 0xb6, 0xfe, 0x17, 0x42, 0xf7, 0x6b, 0x6b, 0xff, 0x7f, 0x7a, 0x35, 0x7f, 0x53, 0x67, 0x78,
+	0x1b, 0x89, 0xfa, 0xbb, 0x77, 0xbd, 0xe1, 0xd7, 0xd6, 0x62, 0xc9, 0xbc, 0xab, 0x25, 0xf3, 0xae,
+	0x97, 0x8c, 0x7c, 0x2c, 0x18, 0xf9, 0x5e, 0x30, 0xf2, 0xb3, 0x60, 0x64, 0x51, 0x30, 0xf2, 0xab,
+	0x60, 0xe4, 0x77, 0xc1, 0xbc, 0xeb, 0x82, 0x91, 0xcf, 0x2b, 0xe6, 0x2d, 0x56, 0xcc, 0xbb, 0x5a,
+	0x31, 0x8f, 0x3e, 0x4c, 0x71, 0x47, 0xfb, 0xe1, 0xbd, 0x4d, 0xe7, 0x51, 0xb9, 0x35, 0x22, 0xaf,
+	0x3f, 0x24, 0xa9, 0xbb, 0x98, 0xc6, 0x5c, 0x62, 0x26, 0x24, 0x28, 0x05, 0x66, 0xde, 0x4b, 0x51,
+	0x64, 0x60, 0x2f, 0x7a, 0x18, 0x97, 0xc7, 0x3f, 0x8a, 0x53, 0x95, 0xba, 0xb9, 0x90, 0x98, 0x69,
+	0xcc, 0x21, 0x77, 0x56, 0x24, 0x0a, 0xe3, 0x48, 0x89, 0xc6, 0xfb, 0x5e, 0x94, 0x40, 0xee, 0x84,
+	0x7e, 0x9b, 0x08, 0x74, 0x4a, 0x8b, 0xdd, 0xae, 0xd9, 0x8f, 0xd6, 0xd9, 0x4b, 0x0d, 0xf9, 0xf9,
+	0x3a, 0x7d, 0x95, 0x8b, 0x3f, 0x5b, 0xa7, 0xaf, 0x32, 0xf3, 0x57, 0xfd, 0x78, 0xbf, 0x92, 0x78,
+	0xfc, 0x67, 0x00, 0x4e, 0xf4, 0x47, 0x4f, 0xc0, 0x03, 0x00, 0x00,
+}
+
+func (this *ExportTraceServiceRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExportTraceServiceRequest)
+	if !ok {
+		that2, ok := that.(ExportTraceServiceRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.ResourceSpans) != len(that1.ResourceSpans) {
+		return false
+	}
+	for i := range this.ResourceSpans {
+		if !this.ResourceSpans[i].Equal(that1.ResourceSpans[i]) {
+			return false
+		}
+	}
+	return true
+}
+func (this *ExportTraceServiceResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExportTraceServiceResponse)
+	if !ok {
+		that2, ok := that.(ExportTraceServiceResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.PartialSuccess.Equal(that1.PartialSuccess) {
+		return false
+	}
+	return true
+}
+func (this *ExportTracePartialSuccess) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ExportTracePartialSuccess)
+	if !ok {
+		that2, ok := that.(ExportTracePartialSuccess)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.RejectedSpans != that1.RejectedSpans {
+		return false
+	}
+	if this.ErrorMessage != that1.ErrorMessage {
+		return false
+	}
+	return true
+}
+func (this *ExportTraceServiceRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&v1.ExportTraceServiceRequest{")
+	if this.ResourceSpans != nil {
+		s = append(s, "ResourceSpans: "+fmt.Sprintf("%#v", this.ResourceSpans)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ExportTraceServiceResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&v1.ExportTraceServiceResponse{")
+	if this.PartialSuccess != nil {
+		s = append(s, "PartialSuccess: "+fmt.Sprintf("%#v", this.PartialSuccess)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ExportTracePartialSuccess) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&v1.ExportTracePartialSuccess{")
+	s = append(s, "RejectedSpans: "+fmt.Sprintf("%#v", this.RejectedSpans)+",\n")
+	s = append(s, "ErrorMessage: "+fmt.Sprintf("%#v", this.ErrorMessage)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringTraceService(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *ExportTraceServiceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportTraceServiceRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.ResourceSpans) > 0 {
+		for _, msg := range m.ResourceSpans {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintTraceService(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *ExportTraceServiceResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportTraceServiceResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.PartialSuccess != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintTraceService(dAtA, i, uint64(m.PartialSuccess.Size()))
+		n1, err := m.PartialSuccess.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	return i, nil
+}
+
+func (m *ExportTracePartialSuccess) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportTracePartialSuccess) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RejectedSpans != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintTraceService(dAtA, i, uint64(m.RejectedSpans))
+	}
+	if len(m.ErrorMessage) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintTraceService(dAtA, i, uint64(len(m.ErrorMessage)))
+		i += copy(dAtA[i:], m.ErrorMessage)
+	}
+	return i, nil
+}
+
+func encodeVarintTraceService(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ExportTraceServiceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ResourceSpans) > 0 {
+		for _, e := range m.ResourceSpans {
+			l = e.Size()
+			n += 1 + l + sovTraceService(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExportTraceServiceResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PartialSuccess != nil {
+		l = m.PartialSuccess.Size()
+		n += 1 + l + sovTraceService(uint64(l))
+	}
+	return n
+}
+
+func (m *ExportTracePartialSuccess) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RejectedSpans != 0 {
+		n += 1 + sovTraceService(uint64(m.RejectedSpans))
+	}
+	l = len(m.ErrorMessage)
+	if l > 0 {
+		n += 1 + l + sovTraceService(uint64(l))
+	}
+	return n
+}
+
+func sovTraceService(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozTraceService(x uint64) (n int) {
+	return sovTraceService(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *ExportTraceServiceRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ExportTraceServiceRequest{`,
+		`ResourceSpans:` + strings.Replace(fmt.Sprintf("%v", this.ResourceSpans), "ResourceSpans", "v1.ResourceSpans", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExportTraceServiceResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ExportTraceServiceResponse{`,
+		`PartialSuccess:` + strings.Replace(fmt.Sprintf("%v", this.PartialSuccess), "ExportTracePartialSuccess", "ExportTracePartialSuccess", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExportTracePartialSuccess) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&ExportTracePartialSuccess{`,
+		`RejectedSpans:` + fmt.Sprintf("%v", this.RejectedSpans) + `,`,
+		`ErrorMessage:` + fmt.Sprintf("%v", this.ErrorMessage) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringTraceService(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *ExportTraceServiceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTraceService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportTraceServiceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportTraceServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResourceSpans", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResourceSpans = append(m.ResourceSpans, &v1.ResourceSpans{})
+			if err := m.ResourceSpans[len(m.ResourceSpans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTraceService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportTraceServiceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTraceService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportTraceServiceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportTraceServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartialSuccess", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.PartialSuccess == nil {
+				m.PartialSuccess = &ExportTracePartialSuccess{}
+			}
+			if err := m.PartialSuccess.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTraceService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportTracePartialSuccess) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTraceService
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportTracePartialSuccess: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportTracePartialSuccess: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectedSpans", wireType)
+			}
+			m.RejectedSpans = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RejectedSpans |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ErrorMessage", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ErrorMessage = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTraceService(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTraceService
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTraceService(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTraceService
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTraceService
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTraceService
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthTraceService
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowTraceService
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipTraceService(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthTraceService
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthTraceService = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTraceService   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package overflow
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// BlockPolicy waits for space in the buffer, up to the timeout if one is given
+	BlockPolicy = "block"
+	// DropPolicy drops the newest records when the buffer is full
+	DropPolicy = "drop"
+	// RejectPolicy rejects the records when the buffer is full, so that the sender can back off
+	RejectPolicy = "reject"
+
+	// The reasons for which the records are dropped
+	TimeoutReason  = "timeout"
+	FullReason     = "full"
+	RejectedReason = "rejected"
+
+	reportInterval = 30 * time.Second
+	// spacePollInterval is the interval at which PutAll checks whether the buffer has space for all the records
+	spacePollInterval = 5 * time.Millisecond
+)
+
+// ErrRejected is returned when a record is rejected since the buffer is full
+var ErrRejected = errors.New("the buffer is full")
+
+type (
+	// Overflow is the configuration of what happens when a record is written to a full buffer
+	Overflow struct {
+		Policy        string `json:"policy"`
+		TimeoutMillis int    `json:"timeoutMillis"`
+	}
+
+	// Handler writes the records to the buffers according to the overflow policy, and counts the dropped records
+	// by the reason. A nil handler blocks until there is space in the buffer.
+	Handler struct {
+		policy       string
+		timeout      time.Duration
+		logger       *zap.SugaredLogger
+		lock         sync.Mutex
+		dropped      map[string]int64
+		reported     map[string]int64
+		lastReported time.Time
+	}
+)
+
+func New(config *Overflow, logger *zap.SugaredLogger) (*Handler, error) {
+	handler := &Handler{
+		policy:       BlockPolicy,
+		logger:       logger,
+		dropped:      make(map[string]int64),
+		reported:     make(map[string]int64),
+		lastReported: time.Now(),
+	}
+	if config == nil {
+		return handler, nil
+	}
+	switch config.Policy {
+	case "", BlockPolicy:
+		if config.TimeoutMillis < 0 {
+			return nil, fmt.Errorf("the overflow timeout should not be negative")
+		}
+		handler.timeout = time.Duration(config.TimeoutMillis) * time.Millisecond
+	case DropPolicy, RejectPolicy:
+		handler.policy = config.Policy
+	default:
+		return nil, fmt.Errorf("unknown overflow policy %s", config.Policy)
+	}
+	return handler, nil
+}
+
+// Put writes the record to the buffer. ErrRejected is returned if the record was rejected or the buffer did not
+// have space within the timeout, while the records dropped by the drop policy are only counted.
+func (handler *Handler) Put(buffer chan string, record string) error {
+	if handler == nil || (handler.policy == BlockPolicy && handler.timeout == 0) {
+		buffer <- record
+		return nil
+	}
+	select {
+	case buffer <- record:
+		return nil
+	default:
+	}
+	switch handler.policy {
+	case DropPolicy:
+		handler.drop(FullReason, 1)
+		return nil
+	case RejectPolicy:
+		handler.drop(RejectedReason, 1)
+		return ErrRejected
+	}
+	timer := time.NewTimer(handler.timeout)
+	defer timer.Stop()
+	select {
+	case buffer <- record:
+		return nil
+	case <-timer.C:
+		handler.drop(TimeoutReason, 1)
+		return ErrRejected
+	}
+}
+
+// PutAll writes the records to the buffer as a whole. The records are dropped or rejected together when the buffer
+// does not have space for all of them, so that a sender retrying the rejected records does not duplicate the ones
+// which were already written. Once accepted, the records are written even if other writers fill the buffer in the
+// meantime.
+func (handler *Handler) PutAll(buffer chan string, records []string) error {
+	if handler != nil && !(handler.policy == BlockPolicy && handler.timeout == 0) &&
+		!handler.waitForSpace(buffer, len(records)) {
+		switch handler.policy {
+		case DropPolicy:
+			handler.drop(FullReason, int64(len(records)))
+			return nil
+		case RejectPolicy:
+			handler.drop(RejectedReason, int64(len(records)))
+		default:
+			handler.drop(TimeoutReason, int64(len(records)))
+		}
+		return ErrRejected
+	}
+	for _, record := range records {
+		buffer <- record
+	}
+	return nil
+}
+
+// waitForSpace returns whether the buffer has space for the given number of records, waiting up to the timeout of
+// the block policy
+func (handler *Handler) waitForSpace(buffer chan string, count int) bool {
+	if cap(buffer)-len(buffer) >= count {
+		return true
+	}
+	if handler.policy != BlockPolicy || count > cap(buffer) {
+		return false
+	}
+	deadline := time.Now().Add(handler.timeout)
+	ticker := time.NewTicker(spacePollInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if cap(buffer)-len(buffer) >= count {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+	}
+	return false
+}
+
+// Dropped returns the number of records dropped for each reason
+func (handler *Handler) Dropped() map[string]int64 {
+	dropped := make(map[string]int64)
+	if handler == nil {
+		return dropped
+	}
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	for reason, count := range handler.dropped {
+		dropped[reason] = count
+	}
+	return dropped
+}
+
+// drop counts the dropped records, and logs the records dropped since the last report at most once per interval, so
+// that the data loss is visible without flooding the logs
+func (handler *Handler) drop(reason string, count int64) {
+	handler.lock.Lock()
+	defer handler.lock.Unlock()
+	handler.dropped[reason] += count
+	if time.Since(handler.lastReported) < reportInterval && len(handler.reported) > 0 {
+		return
+	}
+	reasons := make([]string, 0, len(handler.dropped))
+	for reason, count := range handler.dropped {
+		if count > handler.reported[reason] {
+			reasons = append(reasons, fmt.Sprintf("%d (%s)", count-handler.reported[reason], reason))
+		}
+		handler.reported[reason] = count
+	}
+	sort.Strings(reasons)
+	handler.lastReported = time.Now()
+	handler.logger.Warnf("Dropped %s records since the buffer is full", strings.Join(reasons, ", "))
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package overflow
+
+import (
+	"testing"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+func TestNew(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	tests := []struct {
+		config      *Overflow
+		expectedErr string
+	}{
+		{config: nil},
+		{config: &Overflow{}},
+		{config: &Overflow{Policy: BlockPolicy, TimeoutMillis: 100}},
+		{config: &Overflow{Policy: DropPolicy}},
+		{config: &Overflow{Policy: RejectPolicy}},
+		{config: &Overflow{Policy: BlockPolicy, TimeoutMillis: -1},
+			expectedErr: "the overflow timeout should not be negative"},
+		{config: &Overflow{Policy: "retry"}, expectedErr: "unknown overflow policy retry"},
+	}
+	for _, test := range tests {
+		_, err := New(test.config, logger)
+		if test.expectedErr == "" && err != nil {
+			t.Errorf("Unexpected error for %v : %v", test.config, err)
+		}
+		if test.expectedErr != "" && (err == nil || err.Error() != test.expectedErr) {
+			t.Errorf("Expected the error %s, but received %v", test.expectedErr, err)
+		}
+	}
+}
+
+func TestPut(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	tests := []struct {
+		config          *Overflow
+		expectedErr     error
+		expectedDropped map[string]int64
+	}{
+		{
+			config:          &Overflow{Policy: BlockPolicy, TimeoutMillis: 10},
+			expectedErr:     ErrRejected,
+			expectedDropped: map[string]int64{TimeoutReason: 1},
+		},
+		{
+			config:          &Overflow{Policy: DropPolicy},
+			expectedDropped: map[string]int64{FullReason: 1},
+		},
+		{
+			config:          &Overflow{Policy: RejectPolicy},
+			expectedErr:     ErrRejected,
+			expectedDropped: map[string]int64{RejectedReason: 1},
+		},
+	}
+	for _, test := range tests {
+		handler, err := New(test.config, logger)
+		if err != nil {
+			t.Fatalf("Could not create the handler : %v", err)
+		}
+		buffer := make(chan string, 1)
+		err = handler.Put(buffer, "first")
+		if err != nil {
+			t.Errorf("Unexpected error when the buffer has space for the %s policy : %v", test.config.Policy, err)
+		}
+		err = handler.Put(buffer, "second")
+		if err != test.expectedErr {
+			t.Errorf("Expected the error %v for the %s policy, but received %v", test.expectedErr,
+				test.config.Policy, err)
+		}
+		if record := <-buffer; record != "first" {
+			t.Errorf("Expected the oldest record to be kept, but received %s", record)
+		}
+		dropped := handler.Dropped()
+		if len(dropped) != len(test.expectedDropped) {
+			t.Errorf("Expected the dropped counts %v, but received %v", test.expectedDropped, dropped)
+		}
+		for reason, count := range test.expectedDropped {
+			if dropped[reason] != count {
+				t.Errorf("Expected %d records dropped due to %s, but received %d", count, reason, dropped[reason])
+			}
+		}
+	}
+}
+
+func TestPutAll(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	tests := []struct {
+		config          *Overflow
+		expectedErr     error
+		expectedDropped map[string]int64
+	}{
+		{
+			config:          &Overflow{Policy: BlockPolicy, TimeoutMillis: 10},
+			expectedErr:     ErrRejected,
+			expectedDropped: map[string]int64{TimeoutReason: 2},
+		},
+		{
+			config:          &Overflow{Policy: DropPolicy},
+			expectedDropped: map[string]int64{FullReason: 2},
+		},
+		{
+			config:          &Overflow{Policy: RejectPolicy},
+			expectedErr:     ErrRejected,
+			expectedDropped: map[string]int64{RejectedReason: 2},
+		},
+	}
+	for _, test := range tests {
+		handler, err := New(test.config, logger)
+		if err != nil {
+			t.Fatalf("Could not create the handler : %v", err)
+		}
+		buffer := make(chan string, 2)
+		err = handler.PutAll(buffer, []string{"first"})
+		if err != nil {
+			t.Errorf("Unexpected error when the buffer has space for the %s policy : %v", test.config.Policy, err)
+		}
+		// The buffer has space for only one of the records, hence neither of them should be written
+		err = handler.PutAll(buffer, []string{"second", "third"})
+		if err != test.expectedErr {
+			t.Errorf("Expected the error %v for the %s policy, but received %v", test.expectedErr,
+				test.config.Policy, err)
+		}
+		if len(buffer) != 1 {
+			t.Errorf("Expected only the first record in the buffer for the %s policy, but found %d records",
+				test.config.Policy, len(buffer))
+		}
+		dropped := handler.Dropped()
+		for reason, count := range test.expectedDropped {
+			if dropped[reason] != count {
+				t.Errorf("Expected %d records dropped due to %s, but received %d", count, reason, dropped[reason])
+			}
+		}
+	}
+}
+
+func TestPutWithNilHandler(t *testing.T) {
+	var handler *Handler
+	buffer := make(chan string, 1)
+	err := handler.Put(buffer, "record")
+	if err != nil {
+		t.Errorf("Unexpected error : %v", err)
+	}
+	if len(buffer) != 1 || len(handler.Dropped()) != 0 {
+		t.Errorf("Expected the record to be written without drops")
+	}
+}
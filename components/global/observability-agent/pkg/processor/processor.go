@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package processor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+)
+
+const (
+	// DropAction removes the key from the record
+	DropAction = "drop"
+	// RenameAction moves the value of the key to the target key
+	RenameAction = "rename"
+	// SetAction sets the key to a static value, such as the cluster name or the region
+	SetAction = "set"
+	// CopyAction copies the value of the key to the target key
+	CopyAction = "copy"
+	// ExtractAction sets a key for each named group of the pattern matching the string value of the key
+	ExtractAction = "extract"
+	// HashAction replaces the value of the key, or sets the target key if given, with the SHA-256 hash of the value
+	HashAction = "hash"
+)
+
+type (
+	// Processor is the configuration of a single step of the attribute processing chain
+	Processor struct {
+		Action  string      `json:"action"`
+		Key     string      `json:"key"`
+		Target  string      `json:"target"`
+		Value   interface{} `json:"value"`
+		Pattern string      `json:"pattern"`
+	}
+
+	// Chain applies the configured processors to the records in the given order. A nil chain leaves the records as
+	// they are.
+	Chain struct {
+		steps []func(attributes map[string]interface{})
+	}
+)
+
+// New validates the processor configurations and builds the chain applying them in order
+func New(processors []*Processor) (*Chain, error) {
+	chain := &Chain{}
+	for i, processor := range processors {
+		step, err := newStep(processor)
+		if err != nil {
+			return nil, fmt.Errorf("invalid processor at %d : %v", i, err)
+		}
+		chain.steps = append(chain.steps, step)
+	}
+	return chain, nil
+}
+
+// Process applies the processors to the attributes of a record in place
+func (chain *Chain) Process(attributes map[string]interface{}) {
+	if chain == nil {
+		return
+	}
+	for _, step := range chain.steps {
+		step(attributes)
+	}
+}
+
+func newStep(processor *Processor) (func(attributes map[string]interface{}), error) {
+	if processor == nil {
+		return nil, fmt.Errorf("the processor is empty")
+	}
+	if processor.Key == "" {
+		return nil, fmt.Errorf("the key of the %s processor is empty", processor.Action)
+	}
+	key := processor.Key
+	target := processor.Target
+	switch processor.Action {
+	case DropAction:
+		return func(attributes map[string]interface{}) {
+			delete(attributes, key)
+		}, nil
+	case RenameAction, CopyAction:
+		if target == "" {
+			return nil, fmt.Errorf("the target of the %s processor is empty", processor.Action)
+		}
+		rename := processor.Action == RenameAction
+		return func(attributes map[string]interface{}) {
+			value, ok := attributes[key]
+			if !ok {
+				return
+			}
+			if rename {
+				delete(attributes, key)
+			}
+			attributes[target] = value
+		}, nil
+	case SetAction:
+		if processor.Value == nil {
+			return nil, fmt.Errorf("the value of the set processor is empty")
+		}
+		value := processor.Value
+		return func(attributes map[string]interface{}) {
+			attributes[key] = value
+		}, nil
+	case ExtractAction:
+		pattern, err := regexp.Compile(processor.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile the pattern : %v", err)
+		}
+		names := pattern.SubexpNames()
+		hasNamedGroup := false
+		for _, name := range names {
+			if name != "" {
+				hasNamedGroup = true
+			}
+		}
+		if !hasNamedGroup {
+			return nil, fmt.Errorf("the pattern %s does not have a named group", processor.Pattern)
+		}
+		return func(attributes map[string]interface{}) {
+			value, ok := attributes[key].(string)
+			if !ok {
+				return
+			}
+			match := pattern.FindStringSubmatch(value)
+			for i := 1; i < len(match); i++ {
+				if names[i] != "" {
+					attributes[names[i]] = match[i]
+				}
+			}
+		}, nil
+	case HashAction:
+		if target == "" {
+			target = key
+		}
+		return func(attributes map[string]interface{}) {
+			value, ok := attributes[key]
+			if !ok {
+				return
+			}
+			hash := sha256.Sum256([]byte(fmt.Sprint(value)))
+			attributes[target] = hex.EncodeToString(hash[:])
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown processor action %s", processor.Action)
+	}
+}
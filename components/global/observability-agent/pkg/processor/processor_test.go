@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package processor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProcess(t *testing.T) {
+	processors := []*Processor{
+		{Action: DropAction, Key: "response_ip"},
+		{Action: RenameAction, Key: "destination_workload", Target: "destinationComponent"},
+		{Action: RenameAction, Key: "missing", Target: "other"},
+		{Action: SetAction, Key: "cluster", Value: "dev"},
+		{Action: CopyAction, Key: "requestPath", Target: "requestPathCopy"},
+		{Action: ExtractAction, Key: "requestPath", Pattern: `^/api/(?P<apiVersion>v\d+)/(?P<resource>\w+)`},
+		{Action: HashAction, Key: "userId"},
+		{Action: HashAction, Key: "sourceIp", Target: "sourceIpHash"},
+	}
+	chain, err := New(processors)
+	if err != nil {
+		t.Fatalf("Could not create the chain : %v", err)
+	}
+	attributes := map[string]interface{}{
+		"response_ip":          "10.0.0.1",
+		"destination_workload": "hr",
+		"requestPath":          "/api/v1/pets/12",
+		"userId":               "hello",
+		"sourceIp":             "hello",
+		"responseCode":         200,
+	}
+	chain.Process(attributes)
+	expectedAttributes := map[string]interface{}{
+		"destinationComponent": "hr",
+		"cluster":              "dev",
+		"requestPath":          "/api/v1/pets/12",
+		"requestPathCopy":      "/api/v1/pets/12",
+		"apiVersion":           "v1",
+		"resource":             "pets",
+		"userId":               "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		"sourceIp":             "hello",
+		"sourceIpHash":         "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		"responseCode":         200,
+	}
+	if !reflect.DeepEqual(attributes, expectedAttributes) {
+		t.Errorf("Expected the attributes %v, but received %v", expectedAttributes, attributes)
+	}
+}
+
+func TestProcessWithNilChain(t *testing.T) {
+	var chain *Chain
+	attributes := map[string]interface{}{"destinationComponent": "hr"}
+	chain.Process(attributes)
+	if len(attributes) != 1 || attributes["destinationComponent"] != "hr" {
+		t.Errorf("Expected the attributes to be unchanged, but received %v", attributes)
+	}
+}
+
+func TestNewWithInvalidProcessors(t *testing.T) {
+	tests := []struct {
+		processor   *Processor
+		expectedErr string
+	}{
+		{processor: nil, expectedErr: "invalid processor at 0 : the processor is empty"},
+		{processor: &Processor{Action: DropAction},
+			expectedErr: "invalid processor at 0 : the key of the drop processor is empty"},
+		{processor: &Processor{Action: RenameAction, Key: "a"},
+			expectedErr: "invalid processor at 0 : the target of the rename processor is empty"},
+		{processor: &Processor{Action: CopyAction, Key: "a"},
+			expectedErr: "invalid processor at 0 : the target of the copy processor is empty"},
+		{processor: &Processor{Action: SetAction, Key: "a"},
+			expectedErr: "invalid processor at 0 : the value of the set processor is empty"},
+		{processor: &Processor{Action: ExtractAction, Key: "a", Pattern: "("},
+			expectedErr: "invalid processor at 0 : could not compile the pattern : error parsing regexp: " +
+				"missing closing ): `(`"},
+		{processor: &Processor{Action: ExtractAction, Key: "a", Pattern: "(v1)"},
+			expectedErr: "invalid processor at 0 : the pattern (v1) does not have a named group"},
+		{processor: &Processor{Action: "lowercase", Key: "a"},
+			expectedErr: "invalid processor at 0 : unknown processor action lowercase"},
+	}
+	for _, test := range tests {
+		_, err := New([]*Processor{test.processor})
+		if err == nil || err.Error() != test.expectedErr {
+			t.Errorf("Expected the error %s, but received %v", test.expectedErr, err)
+		}
+	}
+}
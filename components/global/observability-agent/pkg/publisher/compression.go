@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	NoCompression     = "none"
+	GzipCompression   = "gzip"
+	ZstdCompression   = "zstd"
+	SnappyCompression = "snappy"
+)
+
+type (
+	// Compressor compresses the request bodies sent to the server
+	Compressor interface {
+		// ContentEncoding returns the value of the Content-Encoding header, or an empty string if the body is
+		// not compressed
+		ContentEncoding() string
+		NewWriter(w io.Writer) (io.WriteCloser, error)
+	}
+
+	noCompressor   struct{}
+	gzipCompressor struct {
+		level int
+	}
+	zstdCompressor struct {
+		level int
+	}
+	// snappyCompressor uses the snappy framing format, so that the body can be written as a stream
+	snappyCompressor struct{}
+
+	nopWriteCloser struct {
+		io.Writer
+	}
+)
+
+// NewCompressor returns the compressor with the given name. Gzip is used if the name is empty. The level is only
+// used by gzip and zstd, and zero selects the default level of the algorithm.
+func NewCompressor(name string, level int) (Compressor, error) {
+	switch name {
+	case "", GzipCompression:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+			return nil, fmt.Errorf("invalid gzip compression level %d", level)
+		}
+		return &gzipCompressor{level: level}, nil
+	case NoCompression:
+		return &noCompressor{}, nil
+	case ZstdCompression:
+		return &zstdCompressor{level: level}, nil
+	case SnappyCompression:
+		return &snappyCompressor{}, nil
+	default:
+		return nil, fmt.Errorf("unknown compression %s", name)
+	}
+}
+
+func (compressor *noCompressor) ContentEncoding() string {
+	return ""
+}
+
+func (compressor *noCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+func (compressor *gzipCompressor) ContentEncoding() string {
+	return GzipCompression
+}
+
+func (compressor *gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, compressor.level)
+}
+
+func (compressor *zstdCompressor) ContentEncoding() string {
+	return ZstdCompression
+}
+
+func (compressor *zstdCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	if compressor.level > 0 {
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(compressor.level)))
+	}
+	return zstd.NewWriter(w)
+}
+
+func (compressor *snappyCompressor) ContentEncoding() string {
+	return SnappyCompression
+}
+
+func (compressor *snappyCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return snappy.NewBufferedWriter(w), nil
+}
+
+func (writer nopWriteCloser) Close() error {
+	return nil
+}
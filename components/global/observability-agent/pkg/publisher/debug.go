@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+const (
+	StdoutOutput = "stdout"
+
+	defaultDebugMaxFileBytes = 10 * 1024 * 1024
+	defaultDebugMaxBackups   = 3
+)
+
+type (
+	// Debug is the configuration of the exporter which writes the batches to stdout or a file
+	Debug struct {
+		// Output is either stdout or the path of the file, which is rotated once it grows beyond MaxFileBytes
+		Output       string `json:"output"`
+		Pretty       bool   `json:"pretty"`
+		MaxFileBytes int64  `json:"maxFileBytes"`
+		MaxBackups   int    `json:"maxBackups"`
+	}
+
+	// DebugExporter writes each batch as a JSON array on its own line, or indented if Pretty is set
+	DebugExporter struct {
+		Writer io.Writer
+		Pretty bool
+		lock   sync.Mutex
+	}
+
+	// rotatingFile is a file which is renamed to <path>.1 once it reaches the maximum size, keeping up to
+	// maxBackups of the older files as <path>.2, <path>.3 and so on
+	rotatingFile struct {
+		path       string
+		maxBytes   int64
+		maxBackups int
+		file       *os.File
+		size       int64
+	}
+)
+
+func NewDebugExporter(config *Debug) (*DebugExporter, error) {
+	exporter := &DebugExporter{
+		Writer: os.Stdout,
+		Pretty: config.Pretty,
+	}
+	if config.Output == "" || config.Output == StdoutOutput {
+		return exporter, nil
+	}
+	file := &rotatingFile{
+		path:       config.Output,
+		maxBytes:   config.MaxFileBytes,
+		maxBackups: config.MaxBackups,
+	}
+	if file.maxBytes <= 0 {
+		file.maxBytes = defaultDebugMaxFileBytes
+	}
+	if file.maxBackups <= 0 {
+		file.maxBackups = defaultDebugMaxBackups
+	}
+	err := file.open()
+	if err != nil {
+		return nil, err
+	}
+	exporter.Writer = file
+	return exporter, nil
+}
+
+func (exporter *DebugExporter) Name() string {
+	return "debug"
+}
+
+func (exporter *DebugExporter) Export(id string, jsonArr string) error {
+	var buf bytes.Buffer
+	var err error
+	if exporter.Pretty {
+		err = json.Indent(&buf, []byte(jsonArr), "", "  ")
+	} else {
+		err = json.Compact(&buf, []byte(jsonArr))
+	}
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not format the batch : %v", err)}
+	}
+	buf.WriteByte('\n')
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	_, err = exporter.Writer.Write(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("could not write the batch : %v", err)
+	}
+	return nil
+}
+
+func (file *rotatingFile) Write(p []byte) (int, error) {
+	if file.size > 0 && file.size+int64(len(p)) > file.maxBytes {
+		err := file.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := file.file.Write(p)
+	file.size += int64(n)
+	return n, err
+}
+
+func (file *rotatingFile) open() error {
+	f, err := os.OpenFile(file.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open the file %s : %v", file.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("could not read the size of the file %s : %v", file.path, err)
+	}
+	file.file = f
+	file.size = info.Size()
+	return nil
+}
+
+func (file *rotatingFile) rotate() error {
+	err := file.file.Close()
+	if err != nil {
+		return fmt.Errorf("could not close the file %s : %v", file.path, err)
+	}
+	_ = os.Remove(fmt.Sprintf("%s.%d", file.path, file.maxBackups))
+	for i := file.maxBackups - 1; i > 0; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", file.path, i), fmt.Sprintf("%s.%d", file.path, i+1))
+	}
+	err = os.Rename(file.path, file.path+".1")
+	if err != nil {
+		return fmt.Errorf("could not rotate the file %s : %v", file.path, err)
+	}
+	return file.open()
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+func TestDebugExport(t *testing.T) {
+	tests := []struct {
+		pretty   bool
+		expected string
+	}{
+		{false, "[{\"requestPath\":\"/pets\",\"responseCode\":200}]\n"},
+		{true, "[\n  {\n    \"requestPath\": \"/pets\",\n    \"responseCode\": 200\n  }\n]\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		exporter := &DebugExporter{
+			Writer: &buf,
+			Pretty: test.pretty,
+		}
+		err := exporter.Export(testBatchID, "[{\"requestPath\": \"/pets\", \"responseCode\": 200}]")
+		if err != nil {
+			t.Errorf("Unexpected error occurred : %v", err)
+		}
+		if buf.String() != test.expected {
+			t.Errorf("Expected %q, but received : %q", test.expected, buf.String())
+		}
+	}
+}
+
+func TestDebugExportToRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "debug")
+	if err != nil {
+		t.Errorf("Could not create the temporary directory : %v", err)
+		return
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	path := filepath.Join(dir, "batches.json")
+	exporter, err := NewDebugExporter(&Debug{
+		Output:       path,
+		MaxFileBytes: 20,
+		MaxBackups:   2,
+	})
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+		return
+	}
+	for i := 0; i < 4; i++ {
+		err = exporter.Export(testBatchID, fmt.Sprintf("[{\"batch\":%d}]", i))
+		if err != nil {
+			t.Errorf("Unexpected error occurred : %v", err)
+		}
+	}
+	// Each batch fills a file, hence only the last batch and two backups are kept
+	expected := map[string]string{
+		path:        "[{\"batch\":3}]\n",
+		path + ".1": "[{\"batch\":2}]\n",
+		path + ".2": "[{\"batch\":1}]\n",
+	}
+	for file, content := range expected {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Errorf("Could not read the file %s : %v", file, err)
+		}
+		if string(data) != content {
+			t.Errorf("Expected %s to contain %q, but received : %q", file, content, string(data))
+		}
+	}
+	if _, err = os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected only two backups to be kept, but found : %s.3", path)
+	}
+}
+
+func TestFetchInDryRun(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var buf bytes.Buffer
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"requestPath\":\"/pets\"}]", "[{\"requestPath\":\"/\"}]"},
+	}
+	publisher := &Publisher{
+		Logger:    logger,
+		Persister: persister,
+		Exporters: []Exporter{&DebugExporter{Writer: &buf}},
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if persister.committed != 2 {
+		t.Errorf("Expected the batches to be committed, but committed : %d", persister.committed)
+	}
+	expected := "[{\"requestPath\":\"/pets\"}]\n[{\"requestPath\":\"/\"}]\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %q, but received : %q", expected, buf.String())
+	}
+}
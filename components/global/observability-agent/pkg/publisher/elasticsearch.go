@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+)
+
+const (
+	defaultElasticsearchIndex      = "cellery-{pipeline}-{date}"
+	defaultElasticsearchDateFormat = "2006.01.02"
+	elasticsearchBulkPath          = "/_bulk"
+)
+
+type (
+	// Elasticsearch is the configuration of the exporter which indexes the records in Elasticsearch or OpenSearch
+	Elasticsearch struct {
+		Endpoint string            `json:"endpoint"`
+		Username string            `json:"username"`
+		Password string            `json:"password"`
+		Headers  map[string]string `json:"headers"`
+		// Index is the name of the index. {pipeline} is replaced with telemetry or tracing and {date} with the
+		// current date formatted using the Go layout in DateFormat.
+		Index      string `json:"index"`
+		DateFormat string `json:"dateFormat"`
+	}
+
+	// ElasticsearchExporter indexes each record of a batch as a document using the bulk API. Only the documents
+	// which failed are sent again when a batch is retried.
+	ElasticsearchExporter struct {
+		Endpoint   string
+		Username   string
+		Password   string
+		Headers    map[string]string
+		Index      string
+		DateFormat string
+		Pipeline   string
+		HttpClient *http.Client
+		Logger     *zap.SugaredLogger
+		Classifier ResponseClassifier
+
+		lock    sync.Mutex
+		pending map[string]*bulkProgress
+	}
+
+	// bulkProgress keeps the index and the documents already indexed for a batch which has to be retried
+	bulkProgress struct {
+		index    string
+		done     map[int]bool
+		rejected int
+	}
+
+	bulkResponse struct {
+		Errors bool                          `json:"errors"`
+		Items  []map[string]bulkResponseItem `json:"items"`
+	}
+
+	bulkResponseItem struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+)
+
+func NewElasticsearchExporter(config *Elasticsearch, schema codec.Schema, httpClient *http.Client,
+	logger *zap.SugaredLogger) *ElasticsearchExporter {
+	exporter := &ElasticsearchExporter{
+		Endpoint:   strings.TrimSuffix(config.Endpoint, "/"),
+		Username:   config.Username,
+		Password:   config.Password,
+		Headers:    config.Headers,
+		Index:      config.Index,
+		DateFormat: config.DateFormat,
+		Pipeline:   pipelineName(schema),
+		HttpClient: httpClient,
+		Logger:     logger,
+	}
+	if exporter.Index == "" {
+		exporter.Index = defaultElasticsearchIndex
+	}
+	if exporter.DateFormat == "" {
+		exporter.DateFormat = defaultElasticsearchDateFormat
+	}
+	return exporter
+}
+
+func (exporter *ElasticsearchExporter) Name() string {
+	return "elasticsearch"
+}
+
+func (exporter *ElasticsearchExporter) Export(id string, jsonArr string) error {
+	var documents []json.RawMessage
+	err := json.Unmarshal([]byte(jsonArr), &documents)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not unmarshal the records : %v", err)}
+	}
+	progress := exporter.progress(id, time.Now())
+
+	var body bytes.Buffer
+	var sent []int
+	for i, document := range documents {
+		if progress.done[i] {
+			continue
+		}
+		action, err := json.Marshal(map[string]map[string]string{
+			"index": {"_index": progress.index, "_id": id + "-" + strconv.Itoa(i)},
+		})
+		if err != nil {
+			return fmt.Errorf("could not marshal the bulk action : %v", err)
+		}
+		body.Write(action)
+		body.WriteByte('\n')
+		err = json.Compact(&body, document)
+		if err != nil {
+			return &PermanentError{Reason: fmt.Sprintf("could not compact the record : %v", err)}
+		}
+		body.WriteByte('\n')
+		sent = append(sent, i)
+	}
+	if len(sent) == 0 {
+		exporter.finish(id)
+		return nil
+	}
+
+	items, err := exporter.bulk(&body)
+	if err != nil {
+		if _, ok := err.(*PermanentError); ok {
+			exporter.finish(id)
+		}
+		return err
+	}
+	if len(items) != len(sent) {
+		return fmt.Errorf("expected %d items in the bulk response, but received %d", len(sent), len(items))
+	}
+	var retryErr error
+	rejected := 0
+	var rejectReason string
+	for i, item := range items {
+		for _, result := range item {
+			switch {
+			case result.Status >= 200 && result.Status < 300:
+				progress.done[sent[i]] = true
+			case result.Status == http.StatusTooManyRequests || result.Status >= 500:
+				if retryErr == nil {
+					retryErr = &RetryableError{StatusCode: result.Status}
+				}
+			default:
+				progress.done[sent[i]] = true
+				progress.rejected++
+				rejected++
+				if rejectReason == "" && result.Error != nil {
+					rejectReason = result.Error.Type + " : " + result.Error.Reason
+				}
+			}
+		}
+	}
+	if rejected > 0 {
+		exporter.Logger.Errorf("Dropping %d documents since they were rejected : %s", rejected, rejectReason)
+	}
+	if retryErr != nil {
+		exporter.Logger.Warnf("Could not index %d of %d documents, hence they will be retried",
+			len(documents)-len(progress.done), len(documents))
+		return retryErr
+	}
+	exporter.finish(id)
+	if progress.rejected == len(documents) {
+		return &PermanentError{Reason: fmt.Sprintf("all the documents were rejected : %s", rejectReason)}
+	}
+	return nil
+}
+
+// bulk sends the bulk request and returns the results of the actions in the order of the actions
+func (exporter *ElasticsearchExporter) bulk(body io.Reader) ([]map[string]bulkResponseItem, error) {
+	req, err := http.NewRequest("POST", exporter.Endpoint+elasticsearchBulkPath, body)
+	if err != nil {
+		return nil, fmt.Errorf("could not make a new request : %v", err)
+	}
+	for key, value := range exporter.Headers {
+		req.Header.Set(key, value)
+	}
+	if exporter.Username != "" {
+		req.SetBasicAuth(exporter.Username, exporter.Password)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	res, err := exporter.HttpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not receive a response from the server : %v", err)
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, res.Body)
+		_ = res.Body.Close()
+	}()
+	classifier := exporter.Classifier
+	if classifier == nil {
+		classifier = &DefaultResponseClassifier{}
+	}
+	err = classifier.Classify(res)
+	if err != nil {
+		return nil, err
+	}
+	var response bulkResponse
+	err = json.NewDecoder(res.Body).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the bulk response : %v", err)
+	}
+	return response.Items, nil
+}
+
+// progress returns the progress of the batch. The index is decided when the batch is exported for the first time,
+// so that a retried batch does not end up in the index of the next day.
+func (exporter *ElasticsearchExporter) progress(id string, now time.Time) *bulkProgress {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	if exporter.pending == nil {
+		exporter.pending = make(map[string]*bulkProgress)
+	}
+	progress, ok := exporter.pending[id]
+	if !ok {
+		index := strings.Replace(exporter.Index, "{pipeline}", exporter.Pipeline, -1)
+		index = strings.Replace(index, "{date}", now.UTC().Format(exporter.DateFormat), -1)
+		progress = &bulkProgress{
+			index: index,
+			done:  make(map[int]bool),
+		}
+		exporter.pending[id] = progress
+	}
+	return progress
+}
+
+func (exporter *ElasticsearchExporter) finish(id string) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	delete(exporter.pending, id)
+}
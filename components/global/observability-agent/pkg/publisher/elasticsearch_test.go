@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+type (
+	bulkAction struct {
+		Index struct {
+			Index string `json:"_index"`
+			ID    string `json:"_id"`
+		} `json:"index"`
+	}
+)
+
+// respondToBulk answers each document of the bulk requests with the status given for it in the statuses of the
+// request, or with 201 if a status is not given
+func respondToBulk(t *testing.T, statuses ...map[string]int) func(int, *receivedRequest) (int, string) {
+	return func(index int, req *receivedRequest) (int, string) {
+		if req.path != "/_bulk" || req.header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("Unexpected request to %s with the content type %s", req.path, req.header.Get("Content-Type"))
+		}
+		if username, password, _ := (&http.Request{Header: req.header}).BasicAuth(); username != "admin" ||
+			password != "secret" {
+			t.Errorf("Unexpected credentials : %s, %s", username, password)
+		}
+		var items []string
+		for _, document := range bulkDocuments(t, req) {
+			status, ok := statuses[index][document["requestPath"].(string)]
+			if !ok {
+				status = 201
+			}
+			if status >= 300 {
+				items = append(items, fmt.Sprintf("{\"index\":{\"status\":%d,\"error\":{\"type\":\"error_%d\","+
+					"\"reason\":\"failed\"}}}", status, status))
+			} else {
+				items = append(items, fmt.Sprintf("{\"index\":{\"status\":%d}}", status))
+			}
+		}
+		return 200, fmt.Sprintf("{\"errors\":true,\"items\":[%s]}", strings.Join(items, ","))
+	}
+}
+
+// bulkActions returns the actions of the bulk request
+func bulkActions(t *testing.T, req *receivedRequest) []bulkAction {
+	var actions []bulkAction
+	for i, line := range bulkLines(req) {
+		if i%2 != 0 {
+			continue
+		}
+		var action bulkAction
+		err := json.Unmarshal(line, &action)
+		if err != nil {
+			t.Errorf("Error when unmarshalling the action : %v", err)
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// bulkDocuments returns the documents of the bulk request
+func bulkDocuments(t *testing.T, req *receivedRequest) []map[string]interface{} {
+	var documents []map[string]interface{}
+	for i, line := range bulkLines(req) {
+		if i%2 == 0 {
+			continue
+		}
+		var document map[string]interface{}
+		err := json.Unmarshal(line, &document)
+		if err != nil {
+			t.Errorf("Error when unmarshalling the document : %v", err)
+		}
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+func bulkLines(req *receivedRequest) [][]byte {
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(req.body))
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	return lines
+}
+
+func TestElasticsearchExportRetriesFailedDocuments(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondToBulk(t, map[string]int{"/pets": 429, "/owners": 400}, map[string]int{}))
+	defer receiver.server.Close()
+	exporter := NewElasticsearchExporter(&Elasticsearch{
+		Endpoint: receiver.server.URL,
+		Username: "admin",
+		Password: "secret",
+	}, codec.TelemetrySchema, &http.Client{}, logger)
+	jsonArr := "[{\"requestPath\":\"/\"},{\"requestPath\":\"/pets\"},{\"requestPath\":\"/owners\"}]"
+	err = exporter.Export(testBatchID, jsonArr)
+	if _, ok := err.(*RetryableError); !ok {
+		t.Errorf("Expected a retryable error, but received : %v", err)
+	}
+	err = exporter.Export(testBatchID, jsonArr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(receiver.requests) != 2 || len(bulkActions(t, receiver.requests[0])) != 3 ||
+		len(bulkActions(t, receiver.requests[1])) != 1 {
+		t.Errorf("Expected only the failed document to be retried, but received %d requests",
+			len(receiver.requests))
+		return
+	}
+	index := "cellery-telemetry-" + time.Now().UTC().Format("2006.01.02")
+	retried := bulkActions(t, receiver.requests[1])[0].Index
+	if retried.Index != index || retried.ID != testBatchID+"-1" {
+		t.Errorf("Unexpected action for the retried document : %v", retried)
+	}
+	if len(exporter.pending) != 0 {
+		t.Errorf("Expected the batch to be removed from the pending batches, but found : %d", len(exporter.pending))
+	}
+}
+
+func TestElasticsearchExportWithRejectedDocuments(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondToBulk(t, map[string]int{"/pets": 400}))
+	defer receiver.server.Close()
+	exporter := NewElasticsearchExporter(&Elasticsearch{
+		Endpoint: receiver.server.URL,
+		Username: "admin",
+		Password: "secret",
+		Index:    "spans-{pipeline}",
+	}, codec.SpanSchema, &http.Client{}, logger)
+	err = exporter.Export(testBatchID, "[{\"requestPath\":\"/pets\"}]")
+	expectedErr := "all the documents were rejected : error_400 : failed"
+	if err == nil {
+		t.Errorf("An error was not thrown, but expected : %s", expectedErr)
+		return
+	}
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+	if index := bulkActions(t, receiver.requests[0])[0].Index.Index; index != "spans-tracing" {
+		t.Errorf("Unexpected index : %s", index)
+	}
+}
+
+func TestElasticsearchExportWithBadResponse(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 503,
+			Header:     make(http.Header),
+		}
+	})
+	exporter := NewElasticsearchExporter(&Elasticsearch{Endpoint: "http://example.com"}, codec.TelemetrySchema,
+		client, logger)
+	err = exporter.Export(testBatchID, "[{\"requestPath\":\"/pets\"}]")
+	if _, ok := err.(*RetryableError); !ok {
+		t.Errorf("Expected a retryable error, but received : %v", err)
+	}
+}
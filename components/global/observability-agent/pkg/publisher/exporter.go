@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"fmt"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+)
+
+const (
+	spDestination = "sp"
+	// instanceNameField and instanceValueField are the fields of a metric record holding the name and the value of
+	// the metric instance
+	instanceNameField  = "instanceName"
+	instanceValueField = "value"
+)
+
+var (
+	// defaultMetricDimensions are the dimensions of the records exported as the labels or the attributes of the
+	// metrics by default. The other dimensions, such as the request path and the pods, would create a new series for
+	// almost every request.
+	defaultMetricDimensions = []string{"sourceInstance", "sourceComponent", "destinationInstance",
+		"destinationComponent", "requestMethod", "responseCode"}
+	// defaultMeasurements are the dimensions holding the measurements of a request, which are exported as values
+	defaultMeasurements = []string{"responseDurationNanoSec", "requestSizeBytes", "responseSizeBytes"}
+)
+
+type (
+	// Exporter sends the batches drained from the store to a backend other than SP. The returned errors are
+	// handled the same way as the ones from SP, hence a *PermanentError drops the batch for the exporter and any
+	// other error keeps the batch in the store to be exported again with the same ID.
+	Exporter interface {
+		Name() string
+		Export(id string, jsonArr string) error
+	}
+
+	// progressTracker is implemented by the exporters which remember the progress of the batches exported
+	// partially, which is forgotten once the batch leaves the store
+	progressTracker interface {
+		finish(id string)
+	}
+
+	destination struct {
+		name   string
+		export func(id string, jsonArr string) error
+	}
+)
+
+// export sends the batch to SP and the configured exporters. A destination which already accepted the batch is
+// skipped when the batch is retried, so that a failing destination does not cause duplicates in the others.
+func (publisher *Publisher) export(id string, jsonArr string) error {
+	destinations := publisher.destinations()
+	var retryErr error
+	rejected := 0
+	for _, dest := range destinations {
+		if publisher.isExported(id, dest.name) {
+			continue
+		}
+		err := dest.export(id, jsonArr)
+		if err == nil {
+			publisher.markExported(id, dest.name)
+			continue
+		}
+		if _, ok := err.(*PermanentError); ok {
+			publisher.markExported(id, dest.name)
+			rejected++
+			if len(destinations) > 1 {
+				publisher.Logger.Errorf("Dropping the batch for %s since it was rejected : %v", dest.name, err)
+			}
+			if rejected == len(destinations) {
+				return err
+			}
+			continue
+		}
+		if retryErr == nil {
+			retryErr = err
+			if len(destinations) > 1 {
+				retryErr = &destinationError{destination: dest.name, err: err}
+			}
+		}
+	}
+	return retryErr
+}
+
+func (publisher *Publisher) destinations() []destination {
+	var destinations []destination
+	if publisher.SpServerUrl != "" {
+		export := publisher.publish
+		if publisher.Streaming {
+			export = publisher.publishStream
+		}
+		destinations = append(destinations, destination{name: spDestination, export: export})
+	}
+	for _, exporter := range publisher.Exporters {
+		destinations = append(destinations, destination{name: exporter.Name(), export: exporter.Export})
+	}
+	return destinations
+}
+
+func (publisher *Publisher) isExported(id string, name string) bool {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	return publisher.exported[id][name]
+}
+
+func (publisher *Publisher) markExported(id string, name string) {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	if publisher.exported == nil {
+		publisher.exported = make(map[string]map[string]bool)
+	}
+	if publisher.exported[id] == nil {
+		publisher.exported[id] = make(map[string]bool)
+	}
+	publisher.exported[id][name] = true
+}
+
+// forget removes the progress of the batch once it left the store, either published or dropped
+func (publisher *Publisher) forget(id string) {
+	publisher.lock.Lock()
+	delete(publisher.exported, id)
+	publisher.lock.Unlock()
+	for _, exporter := range publisher.Exporters {
+		if tracker, ok := exporter.(progressTracker); ok {
+			tracker.finish(id)
+		}
+	}
+}
+
+// pipelineName returns the name of the pipeline producing the records of the given schema
+func pipelineName(schema codec.Schema) string {
+	if schema == codec.SpanSchema {
+		return "tracing"
+	}
+	return "telemetry"
+}
+
+type destinationError struct {
+	destination string
+	err         error
+}
+
+func (err *destinationError) Error() string {
+	return fmt.Sprintf("%s : %v", err.destination, err.err)
+}
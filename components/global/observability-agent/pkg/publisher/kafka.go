@@ -0,0 +1,277 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+)
+
+const (
+	Lz4Compression = "lz4"
+
+	kafkaAcksNone   = "none"
+	kafkaAcksLeader = "leader"
+	kafkaAcksAll    = "all"
+
+	defaultKafkaTopicPrefix = "cellery-"
+	defaultSpanKeyField     = "traceId"
+	defaultMetricKeyField   = "destinationComponent"
+)
+
+type (
+	// Kafka is the configuration of the exporter which produces the records to a Kafka topic
+	Kafka struct {
+		Brokers  []string `json:"brokers"`
+		ClientID string   `json:"clientId"`
+		// Version is the version of the Kafka brokers, such as 2.1.0
+		Version string `json:"version"`
+		// Topic defaults to cellery-telemetry for the telemetry agent and cellery-tracing for the tracing agent
+		Topic string `json:"topic"`
+		// Topics are the topics of the logentry, tracespan and named pipelines of the telemetry agent by the name of
+		// the pipeline, which default to cellery-<pipeline>
+		Topics map[string]string `json:"topics"`
+		// KeyField is the field of a record used as the message key, which defaults to traceId for the spans and
+		// destinationComponent for the metrics, so that the records of a trace or a component share a partition
+		KeyField string `json:"keyField"`
+		// Acks is either none, leader or all, and defaults to all
+		Acks string `json:"acks"`
+		// Compression is either none, gzip, snappy, lz4 or zstd
+		Compression    string `json:"compression"`
+		TimeoutSeconds int    `json:"timeoutSeconds"`
+	}
+
+	// KafkaExporter produces each record of a batch as a message. Export returns only after the brokers
+	// acknowledged all the messages, hence the batch is committed to the store only after the acks. The records
+	// already acknowledged are remembered, so that a retried batch only produces the messages which failed.
+	KafkaExporter struct {
+		Topic    string
+		Topics   map[string]string
+		KeyField string
+		Producer sarama.SyncProducer
+		Logger   *zap.SugaredLogger
+
+		lock sync.Mutex
+		// pending holds the records of each partially produced batch which were acknowledged
+		pending map[string]map[int]bool
+	}
+)
+
+func NewKafkaExporter(config *Kafka, schema codec.Schema, logger *zap.SugaredLogger) (*KafkaExporter, error) {
+	kafkaConfig, err := newKafkaConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewSyncProducer(config.Brokers, kafkaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the Kafka producer : %v", err)
+	}
+	exporter := &KafkaExporter{
+		Topic:    config.Topic,
+		Topics:   config.Topics,
+		KeyField: config.KeyField,
+		Producer: producer,
+		Logger:   logger,
+	}
+	if exporter.Topic == "" {
+		exporter.Topic = defaultKafkaTopicPrefix + pipelineName(schema)
+	}
+	if exporter.KeyField == "" {
+		exporter.KeyField = defaultMetricKeyField
+		if schema == codec.SpanSchema {
+			exporter.KeyField = defaultSpanKeyField
+		}
+	}
+	return exporter, nil
+}
+
+// ForPipeline returns an exporter which produces the records of the named pipeline to the topic of the pipeline
+// using the same producer
+func (exporter *KafkaExporter) ForPipeline(name string) *KafkaExporter {
+	topic := exporter.Topics[name]
+	if topic == "" {
+		topic = defaultKafkaTopicPrefix + name
+	}
+	return &KafkaExporter{
+		Topic:    topic,
+		Topics:   exporter.Topics,
+		KeyField: exporter.KeyField,
+		Producer: exporter.Producer,
+		Logger:   exporter.Logger,
+	}
+}
+
+// Close closes the producer once the publishers using it have stopped
+func (exporter *KafkaExporter) Close() error {
+	return exporter.Producer.Close()
+}
+
+func newKafkaConfig(config *Kafka) (*sarama.Config, error) {
+	kafkaConfig := sarama.NewConfig()
+	kafkaConfig.Producer.Return.Successes = true
+	kafkaConfig.Producer.Return.Errors = true
+	if config.ClientID != "" {
+		kafkaConfig.ClientID = config.ClientID
+	}
+	if config.Version != "" {
+		version, err := sarama.ParseKafkaVersion(config.Version)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Kafka version %s : %v", config.Version, err)
+		}
+		kafkaConfig.Version = version
+	}
+	if config.TimeoutSeconds > 0 {
+		kafkaConfig.Producer.Timeout = time.Duration(config.TimeoutSeconds) * time.Second
+	}
+	switch config.Acks {
+	case "", kafkaAcksAll:
+		kafkaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	case kafkaAcksLeader:
+		kafkaConfig.Producer.RequiredAcks = sarama.WaitForLocal
+	case kafkaAcksNone:
+		kafkaConfig.Producer.RequiredAcks = sarama.NoResponse
+	default:
+		return nil, fmt.Errorf("unknown Kafka acks %s", config.Acks)
+	}
+	switch config.Compression {
+	case "", NoCompression:
+		kafkaConfig.Producer.Compression = sarama.CompressionNone
+	case GzipCompression:
+		kafkaConfig.Producer.Compression = sarama.CompressionGZIP
+	case SnappyCompression:
+		kafkaConfig.Producer.Compression = sarama.CompressionSnappy
+	case Lz4Compression:
+		kafkaConfig.Producer.Compression = sarama.CompressionLZ4
+	case ZstdCompression:
+		kafkaConfig.Producer.Compression = sarama.CompressionZSTD
+	default:
+		return nil, fmt.Errorf("unknown compression %s", config.Compression)
+	}
+	err := kafkaConfig.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid Kafka configuration : %v", err)
+	}
+	return kafkaConfig, nil
+}
+
+func (exporter *KafkaExporter) Name() string {
+	return "kafka"
+}
+
+func (exporter *KafkaExporter) Export(id string, jsonArr string) error {
+	var records []json.RawMessage
+	err := json.Unmarshal([]byte(jsonArr), &records)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not unmarshal the records : %v", err)}
+	}
+	done := exporter.progress(id)
+	messages := make([]*sarama.ProducerMessage, 0, len(records))
+	for i, record := range records {
+		if done[i] {
+			continue
+		}
+		message := &sarama.ProducerMessage{
+			Topic:    exporter.Topic,
+			Value:    sarama.ByteEncoder(record),
+			Metadata: i,
+		}
+		if key := exporter.keyOf(record); key != "" {
+			message.Key = sarama.StringEncoder(key)
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		exporter.finish(id)
+		return nil
+	}
+	err = exporter.Producer.SendMessages(messages)
+	if err != nil {
+		if producerErrs, ok := err.(sarama.ProducerErrors); ok {
+			failed := make(map[int]bool, len(producerErrs))
+			for _, producerErr := range producerErrs {
+				if i, ok := producerErr.Msg.Metadata.(int); ok {
+					failed[i] = true
+				}
+			}
+			exporter.markDone(id, messages, failed)
+			return fmt.Errorf("could not produce %d of %d messages : %v", len(producerErrs), len(messages),
+				producerErrs[0].Err)
+		}
+		return fmt.Errorf("could not produce the messages : %v", err)
+	}
+	exporter.finish(id)
+	return nil
+}
+
+// progress returns a copy of the records of the batch which were already acknowledged
+func (exporter *KafkaExporter) progress(id string) map[int]bool {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	done := make(map[int]bool)
+	for i := range exporter.pending[id] {
+		done[i] = true
+	}
+	return done
+}
+
+// markDone remembers the records of the messages which did not fail
+func (exporter *KafkaExporter) markDone(id string, messages []*sarama.ProducerMessage, failed map[int]bool) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	if exporter.pending == nil {
+		exporter.pending = make(map[string]map[int]bool)
+	}
+	if exporter.pending[id] == nil {
+		exporter.pending[id] = make(map[int]bool)
+	}
+	for _, message := range messages {
+		if i, ok := message.Metadata.(int); ok && !failed[i] {
+			exporter.pending[id][i] = true
+		}
+	}
+}
+
+func (exporter *KafkaExporter) finish(id string) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	delete(exporter.pending, id)
+}
+
+// keyOf returns the value of the key field of the record, or an empty string if the record does not have it
+func (exporter *KafkaExporter) keyOf(record json.RawMessage) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(record, &fields); err != nil {
+		return ""
+	}
+	switch key := fields[exporter.KeyField].(type) {
+	case nil:
+		return ""
+	case string:
+		return key
+	default:
+		return fmt.Sprint(key)
+	}
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Shopify/sarama"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+// recordingProducer is a sync producer which keeps the messages sent to it
+type recordingProducer struct {
+	sarama.SyncProducer
+	messages []*sarama.ProducerMessage
+	// failures is the number of the next messages which are not acknowledged
+	failures int
+}
+
+func (producer *recordingProducer) SendMessages(messages []*sarama.ProducerMessage) error {
+	producer.messages = append(producer.messages, messages...)
+	var producerErrs sarama.ProducerErrors
+	for _, message := range messages {
+		if producer.failures > 0 {
+			producer.failures--
+			producerErrs = append(producerErrs, &sarama.ProducerError{Msg: message, Err: sarama.ErrNotEnoughReplicas})
+		}
+	}
+	if len(producerErrs) > 0 {
+		return producerErrs
+	}
+	return nil
+}
+
+func newMockKafkaBroker(t *testing.T, topic string, produceResponse *sarama.MockProduceResponse) *sarama.MockBroker {
+	broker := sarama.NewMockBroker(t, 1)
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(topic, 0, broker.BrokerID()),
+		"ProduceRequest": produceResponse,
+	})
+	return broker
+}
+
+func produceRequestCount(broker *sarama.MockBroker) int {
+	count := 0
+	for _, requestResponse := range broker.History() {
+		if _, ok := requestResponse.Request.(*sarama.ProduceRequest); ok {
+			count++
+		}
+	}
+	return count
+}
+
+func TestKafkaExportKeys(t *testing.T) {
+	tests := []struct {
+		schema       codec.Schema
+		jsonArr      string
+		expectedKeys []string
+	}{
+		{
+			schema:       codec.SpanSchema,
+			jsonArr:      `[{"traceId":"1a","spanId":"2b"},{"traceId":"3c","spanId":"4d"},{"spanId":"5e"}]`,
+			expectedKeys: []string{"1a", "3c", ""},
+		},
+		{
+			schema:       codec.TelemetrySchema,
+			jsonArr:      `[{"destinationComponent":"hr","value":1},{"destinationComponent":"stock","value":2}]`,
+			expectedKeys: []string{"hr", "stock"},
+		},
+	}
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	for _, test := range tests {
+		producer := &recordingProducer{}
+		exporter := &KafkaExporter{
+			Topic:    defaultKafkaTopicPrefix + pipelineName(test.schema),
+			KeyField: defaultMetricKeyField,
+			Producer: producer,
+			Logger:   logger,
+		}
+		if test.schema == codec.SpanSchema {
+			exporter.KeyField = defaultSpanKeyField
+		}
+		err = exporter.Export(testBatchID, test.jsonArr)
+		if err != nil {
+			t.Errorf("Unexpected error when exporting : %v", err)
+		}
+		if len(producer.messages) != len(test.expectedKeys) {
+			t.Errorf("Expected %d messages, but received %d", len(test.expectedKeys), len(producer.messages))
+			continue
+		}
+		for i, message := range producer.messages {
+			if message.Topic != exporter.Topic {
+				t.Errorf("Expected the topic %s, but received %s", exporter.Topic, message.Topic)
+			}
+			key := ""
+			if message.Key != nil {
+				encoded, _ := message.Key.Encode()
+				key = string(encoded)
+			}
+			if key != test.expectedKeys[i] {
+				t.Errorf("Expected the key %q, but received %q", test.expectedKeys[i], key)
+			}
+		}
+	}
+}
+
+func TestKafkaExportWithMockBroker(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	broker := newMockKafkaBroker(t, "cellery-telemetry", sarama.NewMockProduceResponse(t))
+	defer broker.Close()
+
+	exporter, err := NewKafkaExporter(&Kafka{Brokers: []string{broker.Addr()}, Compression: GzipCompression},
+		codec.TelemetrySchema, logger)
+	if err != nil {
+		t.Fatalf("Could not create the Kafka exporter : %v", err)
+	}
+	defer exporter.Producer.Close()
+	if exporter.Topic != "cellery-telemetry" || exporter.KeyField != defaultMetricKeyField {
+		t.Errorf("Unexpected topic %s or key field %s", exporter.Topic, exporter.KeyField)
+	}
+	err = exporter.Export(testBatchID,
+		`[{"destinationComponent":"hr","value":1},{"destinationComponent":"stock","value":2}]`)
+	if err != nil {
+		t.Errorf("Unexpected error when exporting : %v", err)
+	}
+	if produceRequestCount(broker) == 0 {
+		t.Error("Expected the messages to be produced to the broker")
+	}
+}
+
+func TestKafkaExportWithBrokerError(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	broker := newMockKafkaBroker(t, "cellery-tracing", sarama.NewMockProduceResponse(t).
+		SetError("cellery-tracing", 0, sarama.ErrNotEnoughReplicas))
+	defer broker.Close()
+
+	exporter, err := NewKafkaExporter(&Kafka{Brokers: []string{broker.Addr()}}, codec.SpanSchema, logger)
+	if err != nil {
+		t.Fatalf("Could not create the Kafka exporter : %v", err)
+	}
+	defer exporter.Producer.Close()
+	err = exporter.Export(testBatchID, `[{"traceId":"1a","spanId":"2b"}]`)
+	if err == nil {
+		t.Fatal("Expected an error when the broker does not acknowledge the messages")
+	}
+	if _, ok := err.(*PermanentError); ok {
+		t.Errorf("Expected the batch to be retried, but received a permanent error : %v", err)
+	}
+	expectedErr := fmt.Sprintf("could not produce 1 of 1 messages : %v", sarama.ErrNotEnoughReplicas)
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error %q, but received %q", expectedErr, err.Error())
+	}
+}
+
+func TestKafkaExportWithPartialFailure(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	producer := &recordingProducer{failures: 1}
+	exporter := &KafkaExporter{
+		Topic:    "cellery-telemetry",
+		KeyField: defaultMetricKeyField,
+		Producer: producer,
+		Logger:   logger,
+	}
+	jsonArr := `[{"destinationComponent":"hr"},{"destinationComponent":"stock"},{"destinationComponent":"pets"}]`
+	err = exporter.Export(testBatchID, jsonArr)
+	if err == nil {
+		t.Fatal("Expected an error when a message is not acknowledged")
+	}
+	// Only the message which failed is produced again when the batch is retried
+	producer.messages = nil
+	err = exporter.Export(testBatchID, jsonArr)
+	if err != nil {
+		t.Errorf("Unexpected error when exporting : %v", err)
+	}
+	if len(producer.messages) != 1 || string(producer.messages[0].Value.(sarama.ByteEncoder)) !=
+		`{"destinationComponent":"hr"}` {
+		t.Errorf("Expected only the failed message to be produced again, but received %v", producer.messages)
+	}
+	if len(exporter.pending) != 0 {
+		t.Errorf("Expected the progress of the batch to be removed, but received %v", exporter.pending)
+	}
+}
+
+func TestKafkaExporterForPipeline(t *testing.T) {
+	producer := &recordingProducer{}
+	exporter := &KafkaExporter{
+		Topic:    "cellery-telemetry",
+		Topics:   map[string]string{"logentry": "access-logs"},
+		KeyField: defaultMetricKeyField,
+		Producer: producer,
+	}
+	for pipeline, expectedTopic := range map[string]string{"logentry": "access-logs", "audit": "cellery-audit"} {
+		err := exporter.ForPipeline(pipeline).Export(testBatchID, `[{"destinationComponent":"hr"}]`)
+		if err != nil {
+			t.Errorf("Unexpected error when exporting : %v", err)
+		}
+		message := producer.messages[len(producer.messages)-1]
+		if message.Topic != expectedTopic {
+			t.Errorf("Expected the topic %s for the %s pipeline, but received %s", expectedTopic, pipeline,
+				message.Topic)
+		}
+	}
+}
+
+func TestNewKafkaExporterWithInvalidConfig(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	tests := []struct {
+		config      *Kafka
+		expectedErr string
+	}{
+		{
+			config:      &Kafka{Brokers: []string{"localhost:9092"}, Acks: "some"},
+			expectedErr: "unknown Kafka acks some",
+		},
+		{
+			config:      &Kafka{Brokers: []string{"localhost:9092"}, Compression: "brotli"},
+			expectedErr: "unknown compression brotli",
+		},
+		{
+			config:      &Kafka{Brokers: []string{"localhost:9092"}, Version: "latest"},
+			expectedErr: "invalid Kafka version latest : invalid version `latest`",
+		},
+	}
+	for _, test := range tests {
+		_, err = NewKafkaExporter(test.config, codec.TelemetrySchema, logger)
+		if err == nil {
+			t.Errorf("Expected error %q, but received nil", test.expectedErr)
+		} else if err.Error() != test.expectedErr {
+			t.Errorf("Expected error %q, but received %q", test.expectedErr, err.Error())
+		}
+	}
+}
@@ -0,0 +1,469 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// nolint:lll
+// Generates the Go types of the OTLP messages from the protos copied from opentelemetry-proto. Each package is
+// generated on its own, since protoc-gen-gogoslick expects all the files of a run to be in the same package.
+//go:generate protoc -I../otlp --gogoslick_out=paths=source_relative:../otlp opentelemetry/proto/collector/metrics/v1/metrics_service.proto
+//go:generate protoc -I../otlp --gogoslick_out=paths=source_relative:../otlp opentelemetry/proto/collector/trace/v1/trace_service.proto
+//go:generate protoc -I../otlp --gogoslick_out=paths=source_relative:../otlp opentelemetry/proto/common/v1/common.proto
+//go:generate protoc -I../otlp --gogoslick_out=paths=source_relative:../otlp opentelemetry/proto/metrics/v1/metrics.proto
+//go:generate protoc -I../otlp --gogoslick_out=paths=source_relative:../otlp opentelemetry/proto/resource/v1/resource.proto
+//go:generate protoc -I../otlp --gogoslick_out=paths=source_relative:../otlp opentelemetry/proto/trace/v1/trace.proto
+
+package publisher
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+	collectormetricspb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/collector/metrics/v1"
+	collectortracepb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/collector/trace/v1"
+	commonpb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/common/v1"
+	metricspb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/metrics/v1"
+	resourcepb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/resource/v1"
+	tracepb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/trace/v1"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
+)
+
+const (
+	otlpTracesPath        = "/v1/traces"
+	otlpMetricsPath       = "/v1/metrics"
+	otlpScopeName         = "cellery-observability-agent"
+	defaultOtlpMetricName = "cellery_requests"
+	defaultOtlpStreamTTL  = time.Hour
+)
+
+type (
+	// Otlp is the configuration of the OTLP/HTTP exporter
+	Otlp struct {
+		// Endpoint is the base URL of the collector, to which the /v1/traces and /v1/metrics paths are appended
+		Endpoint string            `json:"endpoint"`
+		Headers  map[string]string `json:"headers"`
+		// MetricName is used for the records which do not carry the name of the metric instance
+		MetricName string `json:"metricName"`
+		// Attributes are the dimensions which become the attributes of the data points. When it is empty, the source
+		// and destination instances and components, the request method and the response code are used.
+		Attributes []string `json:"attributes"`
+		// Measurements are the dimensions added up into the <metric>_<measurement> metrics instead of becoming
+		// attributes. When it is empty, the response duration and the request and response sizes are used.
+		Measurements []string `json:"measurements"`
+	}
+
+	// OtlpExporter exports the spans of the tracing agent and the metrics of the telemetry agent to an
+	// OpenTelemetry collector using OTLP/HTTP with Protobuf payloads
+	OtlpExporter struct {
+		Endpoint     string
+		Headers      map[string]string
+		MetricName   string
+		Attributes   []string
+		Measurements []string
+		Schema       codec.Schema
+		HttpClient   *http.Client
+		Logger       *zap.SugaredLogger
+		Classifier   ResponseClassifier
+
+		lock sync.Mutex
+		// lastExport is the time the metrics were last exported, and streamExports is the time each stream, a metric
+		// with a set of attributes, was last exported. They are the start times of the next delta data points.
+		lastExport    time.Time
+		streamExports map[string]time.Time
+	}
+
+	otlpMetric struct {
+		name            string
+		dataPoints      []*otlpDataPoint
+		dataPointsByKey map[string]*otlpDataPoint
+	}
+
+	otlpDataPoint struct {
+		stream      string
+		attributes  map[string]interface{}
+		intValue    int64
+		doubleValue float64
+		isDouble    bool
+	}
+)
+
+func NewOtlpExporter(config *Otlp, schema codec.Schema, httpClient *http.Client,
+	logger *zap.SugaredLogger) *OtlpExporter {
+	exporter := &OtlpExporter{
+		Endpoint:      strings.TrimSuffix(config.Endpoint, "/"),
+		Headers:       config.Headers,
+		MetricName:    config.MetricName,
+		Attributes:    config.Attributes,
+		Measurements:  config.Measurements,
+		Schema:        schema,
+		HttpClient:    httpClient,
+		Logger:        logger,
+		lastExport:    time.Now(),
+		streamExports: make(map[string]time.Time),
+	}
+	if exporter.MetricName == "" {
+		exporter.MetricName = defaultOtlpMetricName
+	}
+	if len(exporter.Attributes) == 0 {
+		exporter.Attributes = defaultMetricDimensions
+	}
+	if len(exporter.Measurements) == 0 {
+		exporter.Measurements = defaultMeasurements
+	}
+	return exporter
+}
+
+func (exporter *OtlpExporter) Name() string {
+	return "otlp"
+}
+
+func (exporter *OtlpExporter) Export(id string, jsonArr string) error {
+	if exporter.Schema == codec.SpanSchema {
+		request, err := toTraceRequest(jsonArr)
+		if err != nil {
+			return &PermanentError{Reason: fmt.Sprintf("could not convert the batch to OTLP : %v", err)}
+		}
+		return exporter.send(otlpTracesPath, request)
+	}
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	now := time.Now()
+	request, streams, err := exporter.toMetricsRequest(jsonArr, now)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not convert the batch to OTLP : %v", err)}
+	}
+	err = exporter.send(otlpMetricsPath, request)
+	if err != nil {
+		return err
+	}
+	// The start times only move forward once the data points are accepted, so that a retried batch covers the same
+	// interval
+	exporter.lastExport = now
+	for _, stream := range streams {
+		exporter.streamExports[stream] = now
+	}
+	for stream, exported := range exporter.streamExports {
+		if now.Sub(exported) > defaultOtlpStreamTTL {
+			delete(exporter.streamExports, stream)
+		}
+	}
+	return nil
+}
+
+func (exporter *OtlpExporter) send(path string, message proto.Message) error {
+	bytesArr, err := proto.Marshal(message)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not marshal the OTLP request : %v", err)}
+	}
+	var buf bytes.Buffer
+	g := gzip.NewWriter(&buf)
+	if _, err = g.Write(bytesArr); err != nil {
+		return fmt.Errorf("could not write to buffer : %v", err)
+	}
+	if err = g.Close(); err != nil {
+		return fmt.Errorf("could not close the gzip writer : %v", err)
+	}
+	req, err := http.NewRequest("POST", exporter.Endpoint+path, &buf)
+	if err != nil {
+		return fmt.Errorf("could not make a new request : %v", err)
+	}
+	for key, value := range exporter.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	return doRequest(exporter.HttpClient, req, exporter.Classifier)
+}
+
+// toTraceRequest converts the spans into an ExportTraceServiceRequest, grouping the spans by the service
+func toTraceRequest(jsonArr string) (*collectortracepb.ExportTraceServiceRequest, error) {
+	var spans []tracing.ProcessedSpan
+	err := json.Unmarshal([]byte(jsonArr), &spans)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal the spans : %v", err)
+	}
+	request := &collectortracepb.ExportTraceServiceRequest{}
+	spansByService := make(map[string]*tracepb.ScopeSpans)
+	for _, span := range spans {
+		scopeSpans, ok := spansByService[span.ServiceName]
+		if !ok {
+			scopeSpans = &tracepb.ScopeSpans{Scope: otlpScope()}
+			spansByService[span.ServiceName] = scopeSpans
+			request.ResourceSpans = append(request.ResourceSpans, &tracepb.ResourceSpans{
+				Resource: &resourcepb.Resource{
+					Attributes: []*commonpb.KeyValue{otlpKeyValue("service.name", span.ServiceName)},
+				},
+				ScopeSpans: []*tracepb.ScopeSpans{scopeSpans},
+			})
+		}
+		otlpSpan, err := toOtlpSpan(span)
+		if err != nil {
+			return nil, err
+		}
+		scopeSpans.Spans = append(scopeSpans.Spans, otlpSpan)
+	}
+	return request, nil
+}
+
+func toOtlpSpan(span tracing.ProcessedSpan) (*tracepb.Span, error) {
+	traceID, err := decodeOtlpID(span.TraceID, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace id %s : %v", span.TraceID, err)
+	}
+	spanID, err := decodeOtlpID(span.ID, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span id %s : %v", span.ID, err)
+	}
+	start := uint64(span.Timestamp) * uint64(time.Millisecond)
+	otlpSpan := &tracepb.Span{
+		TraceId:           traceID,
+		SpanId:            spanID,
+		Name:              span.Name,
+		Kind:              otlpSpanKind(span.SpanKind),
+		StartTimeUnixNano: start,
+		EndTimeUnixNano:   start + uint64(span.Duration)*uint64(time.Millisecond),
+	}
+	if span.ParentID != "" {
+		otlpSpan.ParentSpanId, err = decodeOtlpID(span.ParentID, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent span id %s : %v", span.ParentID, err)
+		}
+	}
+	if span.Tags != "" {
+		var tags map[string]interface{}
+		decoder := json.NewDecoder(strings.NewReader(span.Tags))
+		decoder.UseNumber()
+		if err = decoder.Decode(&tags); err != nil {
+			return nil, fmt.Errorf("could not unmarshal the tags of the span %s : %v", span.ID, err)
+		}
+		for _, key := range sortedKeys(tags) {
+			otlpSpan.Attributes = append(otlpSpan.Attributes, otlpKeyValue(key, tags[key]))
+		}
+	}
+	return otlpSpan, nil
+}
+
+// toMetricsRequest converts the telemetry records into an ExportMetricsServiceRequest and returns the streams in it.
+// The records are exported as the metrics named by their instance names, and the values of the records having the
+// same attributes are added up into a delta data point of a monotonic sum. A record without a value counts as one.
+// The measurements of the records are added up the same way into the <metric>_<measurement> metrics. Each data point
+// starts when its stream was last exported.
+func (exporter *OtlpExporter) toMetricsRequest(jsonArr string,
+	now time.Time) (*collectormetricspb.ExportMetricsServiceRequest, []string, error) {
+	decoder := json.NewDecoder(strings.NewReader(jsonArr))
+	decoder.UseNumber()
+	var records []map[string]interface{}
+	err := decoder.Decode(&records)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not unmarshal the records : %v", err)
+	}
+	var metrics []*otlpMetric
+	metricsByName := make(map[string]*otlpMetric)
+	dataPointOf := func(name string, attributes map[string]interface{}) *otlpDataPoint {
+		metric, ok := metricsByName[name]
+		if !ok {
+			metric = &otlpMetric{name: name, dataPointsByKey: make(map[string]*otlpDataPoint)}
+			metricsByName[name] = metric
+			metrics = append(metrics, metric)
+		}
+		key := attributesKey(attributes)
+		dataPoint, ok := metric.dataPointsByKey[key]
+		if !ok {
+			dataPoint = &otlpDataPoint{stream: name + "\xff" + key, attributes: attributes}
+			metric.dataPointsByKey[key] = dataPoint
+			metric.dataPoints = append(metric.dataPoints, dataPoint)
+		}
+		return dataPoint
+	}
+	for _, record := range records {
+		name := exporter.MetricName
+		if instanceName, ok := record[instanceNameField].(string); ok && instanceName != "" {
+			name = instanceName
+		}
+		attributes := make(map[string]interface{})
+		for _, attribute := range exporter.Attributes {
+			if value, ok := record[attribute]; ok && value != nil {
+				attributes[attribute] = value
+			}
+		}
+		dataPointOf(name, attributes).add(record[instanceValueField])
+		for _, measurement := range exporter.Measurements {
+			if value, ok := record[measurement].(json.Number); ok {
+				dataPointOf(name+"_"+measurement, attributes).add(value)
+			}
+		}
+	}
+
+	var streams []string
+	scopeMetrics := &metricspb.ScopeMetrics{Scope: otlpScope()}
+	for _, metric := range metrics {
+		sum := &metricspb.Sum{
+			AggregationTemporality: metricspb.AGGREGATION_TEMPORALITY_DELTA,
+			IsMonotonic:            true,
+		}
+		for _, dataPoint := range metric.dataPoints {
+			start, ok := exporter.streamExports[dataPoint.stream]
+			if !ok {
+				start = exporter.lastExport
+			}
+			sum.DataPoints = append(sum.DataPoints, dataPoint.toNumberDataPoint(start, now))
+			streams = append(streams, dataPoint.stream)
+		}
+		scopeMetrics.Metrics = append(scopeMetrics.Metrics, &metricspb.Metric{
+			Name: metric.name,
+			Data: &metricspb.Metric_Sum{Sum: sum},
+		})
+	}
+	return &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource:     &resourcepb.Resource{},
+			ScopeMetrics: []*metricspb.ScopeMetrics{scopeMetrics},
+		}},
+	}, streams, nil
+}
+
+// add adds the value of a record to the data point. Integer values are kept as integers unless a fractional value is
+// added to the same data point.
+func (dataPoint *otlpDataPoint) add(value interface{}) {
+	number, ok := value.(json.Number)
+	if !ok {
+		dataPoint.intValue++
+		return
+	}
+	if i, err := number.Int64(); err == nil {
+		dataPoint.intValue += i
+	} else if f, err := number.Float64(); err == nil {
+		dataPoint.doubleValue += f
+		dataPoint.isDouble = true
+	} else {
+		dataPoint.intValue++
+	}
+}
+
+func (dataPoint *otlpDataPoint) toNumberDataPoint(start time.Time, now time.Time) *metricspb.NumberDataPoint {
+	numberDataPoint := &metricspb.NumberDataPoint{
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		TimeUnixNano:      uint64(now.UnixNano()),
+	}
+	if dataPoint.isDouble {
+		numberDataPoint.Value = &metricspb.NumberDataPoint_AsDouble{
+			AsDouble: dataPoint.doubleValue + float64(dataPoint.intValue),
+		}
+	} else {
+		numberDataPoint.Value = &metricspb.NumberDataPoint_AsInt{AsInt: dataPoint.intValue}
+	}
+	for _, key := range sortedKeys(dataPoint.attributes) {
+		numberDataPoint.Attributes = append(numberDataPoint.Attributes, otlpKeyValue(key, dataPoint.attributes[key]))
+	}
+	return numberDataPoint
+}
+
+// attributesKey identifies a set of attributes regardless of the order of the keys
+func attributesKey(attributes map[string]interface{}) string {
+	var builder strings.Builder
+	for _, key := range sortedKeys(attributes) {
+		builder.WriteString(key)
+		builder.WriteString("=")
+		builder.WriteString(labelValueOf(attributes[key]))
+		builder.WriteString("\xff")
+	}
+	return builder.String()
+}
+
+func otlpScope() *commonpb.InstrumentationScope {
+	return &commonpb.InstrumentationScope{Name: otlpScopeName}
+}
+
+// otlpKeyValue converts an attribute into a KeyValue. Nested objects and arrays are converted to JSON strings.
+func otlpKeyValue(key string, value interface{}) *commonpb.KeyValue {
+	anyValue := &commonpb.AnyValue{}
+	switch v := value.(type) {
+	case string:
+		anyValue.Value = &commonpb.AnyValue_StringValue{StringValue: v}
+	case bool:
+		anyValue.Value = &commonpb.AnyValue_BoolValue{BoolValue: v}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			anyValue.Value = &commonpb.AnyValue_IntValue{IntValue: i}
+		} else if f, err := v.Float64(); err == nil {
+			anyValue.Value = &commonpb.AnyValue_DoubleValue{DoubleValue: f}
+		} else {
+			anyValue.Value = &commonpb.AnyValue_StringValue{StringValue: v.String()}
+		}
+	case int64:
+		anyValue.Value = &commonpb.AnyValue_IntValue{IntValue: v}
+	case float64:
+		anyValue.Value = &commonpb.AnyValue_DoubleValue{DoubleValue: v}
+	case nil:
+	default:
+		str, _ := json.Marshal(v)
+		anyValue.Value = &commonpb.AnyValue_StringValue{StringValue: string(str)}
+	}
+	return &commonpb.KeyValue{Key: key, Value: anyValue}
+}
+
+func otlpSpanKind(kind string) tracepb.Span_SpanKind {
+	switch strings.ToUpper(kind) {
+	case "SERVER":
+		return tracepb.SPAN_KIND_SERVER
+	case "CLIENT":
+		return tracepb.SPAN_KIND_CLIENT
+	case "PRODUCER":
+		return tracepb.SPAN_KIND_PRODUCER
+	case "CONSUMER":
+		return tracepb.SPAN_KIND_CONSUMER
+	default:
+		return tracepb.SPAN_KIND_UNSPECIFIED
+	}
+}
+
+// decodeOtlpID decodes a hex ID, which may have lost its leading zeros, into the given number of bytes
+func decodeOtlpID(id string, size int) ([]byte, error) {
+	if len(id) > size*2 {
+		return nil, fmt.Errorf("id is longer than %d bytes", size)
+	}
+	return hex.DecodeString(strings.Repeat("0", size*2-len(id)) + id)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
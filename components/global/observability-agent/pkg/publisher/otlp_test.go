@@ -0,0 +1,300 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	collectormetricspb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/collector/metrics/v1"
+	collectortracepb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/collector/trace/v1"
+	commonpb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/common/v1"
+	metricspb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/metrics/v1"
+	tracepb "github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/otlp/opentelemetry/proto/trace/v1"
+)
+
+var (
+	testSpanStr = "[{\"traceId\":\"b55a0f7f20d36e49f8612bac4311791d\",\"parentId\":\"1bbbe537\"," +
+		"\"id\":\"ae295f3a4bbbe537\",\"operationName\":\"get /pets\",\"serviceName\":\"pet-be\"," +
+		"\"spanKind\":\"SERVER\",\"timestamp\":1559000000000,\"duration\":20," +
+		"\"tags\":\"{\\\"http.status_code\\\":200,\\\"component\\\":\\\"proxy\\\"}\"}]"
+)
+
+// otlpAttributes converts the KeyValue messages into a map of the string representation of the values
+func otlpAttributes(keyValues []*commonpb.KeyValue) map[string]string {
+	attributes := make(map[string]string)
+	for _, keyValue := range keyValues {
+		switch value := keyValue.Value.Value.(type) {
+		case *commonpb.AnyValue_StringValue:
+			attributes[keyValue.Key] = value.StringValue
+		case *commonpb.AnyValue_IntValue:
+			attributes[keyValue.Key] = fmt.Sprint(value.IntValue)
+		default:
+			attributes[keyValue.Key] = fmt.Sprint(value)
+		}
+	}
+	return attributes
+}
+
+func TestOtlpExportTraces(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(nil)
+	defer receiver.server.Close()
+	exporter := NewOtlpExporter(&Otlp{
+		Endpoint: receiver.server.URL + "/",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+	}, codec.SpanSchema, &http.Client{}, logger)
+	err = exporter.Export(testBatchID, testSpanStr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(receiver.requests) != 1 || receiver.requests[0].path != "/v1/traces" {
+		t.Errorf("Expected a single request to /v1/traces, but received : %d", len(receiver.requests))
+		return
+	}
+	if receiver.requests[0].header.Get("Authorization") != "Bearer token" ||
+		receiver.requests[0].header.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("Unexpected headers : %v", receiver.requests[0].header)
+	}
+	request := &collectortracepb.ExportTraceServiceRequest{}
+	err = proto.Unmarshal(receiver.requests[0].gunzip(t), request)
+	if err != nil {
+		t.Errorf("Error when unmarshalling the request : %v", err)
+		return
+	}
+	if len(request.ResourceSpans) != 1 || len(request.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Errorf("Expected the spans of a single service, but received : %v", request.ResourceSpans)
+		return
+	}
+	resource := otlpAttributes(request.ResourceSpans[0].Resource.Attributes)
+	if resource["service.name"] != "pet-be" {
+		t.Errorf("Unexpected resource attributes : %v", resource)
+	}
+	scopeSpans := request.ResourceSpans[0].ScopeSpans[0]
+	if scopeSpans.Scope.Name != otlpScopeName {
+		t.Errorf("Unexpected scope : %v", scopeSpans.Scope)
+	}
+	if len(scopeSpans.Spans) != 1 {
+		t.Errorf("Expected 1 span, but received : %d", len(scopeSpans.Spans))
+		return
+	}
+	span := scopeSpans.Spans[0]
+	if hex.EncodeToString(span.TraceId) != "b55a0f7f20d36e49f8612bac4311791d" {
+		t.Errorf("Unexpected trace id : %x", span.TraceId)
+	}
+	if hex.EncodeToString(span.ParentSpanId) != "000000001bbbe537" {
+		t.Errorf("Unexpected parent span id : %x", span.ParentSpanId)
+	}
+	if span.Name != "get /pets" || span.Kind != tracepb.SPAN_KIND_SERVER {
+		t.Errorf("Unexpected span name or kind : %s, %v", span.Name, span.Kind)
+	}
+	if span.StartTimeUnixNano != uint64(1559000000000*time.Millisecond) ||
+		span.EndTimeUnixNano-span.StartTimeUnixNano != uint64(20*time.Millisecond) {
+		t.Errorf("Unexpected span times : %d, %d", span.StartTimeUnixNano, span.EndTimeUnixNano)
+	}
+	tags := otlpAttributes(span.Attributes)
+	if fmt.Sprint(tags) != "map[component:proxy http.status_code:200]" {
+		t.Errorf("Unexpected span attributes : %v", tags)
+	}
+}
+
+func TestOtlpExportMetrics(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(nil)
+	defer receiver.server.Close()
+	exporter := NewOtlpExporter(&Otlp{Endpoint: receiver.server.URL}, codec.TelemetrySchema, &http.Client{},
+		logger)
+	batch := "[{\"instanceName\":\"requestsize\",\"value\":1024,\"requestId\":\"a\"," +
+		"\"destinationComponent\":\"hr\",\"responseDurationNanoSec\":100},{\"instanceName\":\"requestsize\"," +
+		"\"value\":2048,\"requestId\":\"b\",\"destinationComponent\":\"hr\",\"responseDurationNanoSec\":200}," +
+		"{\"instanceName\":\"requestduration\",\"value\":1.5},{\"requestPath\":\"/pets\",\"traceId\":\"c\"," +
+		"\"responseCode\":200}]"
+	err = exporter.Export(testBatchID, batch)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(receiver.requests) != 1 || receiver.requests[0].path != "/v1/metrics" {
+		t.Errorf("Expected a single request to /v1/metrics, but received : %d", len(receiver.requests))
+		return
+	}
+	request := &collectormetricspb.ExportMetricsServiceRequest{}
+	err = proto.Unmarshal(receiver.requests[0].gunzip(t), request)
+	if err != nil {
+		t.Errorf("Error when unmarshalling the request : %v", err)
+		return
+	}
+	if len(request.ResourceMetrics) != 1 || len(request.ResourceMetrics[0].ScopeMetrics) != 1 {
+		t.Errorf("Expected a single scope, but received : %v", request.ResourceMetrics)
+		return
+	}
+	metrics := request.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	if len(metrics) != 4 {
+		t.Errorf("Expected 4 metrics, but received : %d", len(metrics))
+		return
+	}
+	var names []string
+	for _, metric := range metrics {
+		names = append(names, metric.Name)
+		sum := metric.GetSum()
+		if sum == nil || !sum.IsMonotonic || sum.AggregationTemporality != metricspb.AGGREGATION_TEMPORALITY_DELTA {
+			t.Errorf("Expected %s to be a monotonic delta sum, but received : %v", metric.Name, metric.Data)
+			return
+		}
+		for _, dataPoint := range sum.DataPoints {
+			if dataPoint.StartTimeUnixNano >= dataPoint.TimeUnixNano {
+				t.Errorf("Expected the data points of %s to start before they end, but received : %v",
+					metric.Name, dataPoint)
+			}
+		}
+	}
+	if fmt.Sprint(names) != "[requestsize requestsize_responseDurationNanoSec requestduration "+
+		defaultOtlpMetricName+"]" {
+		t.Errorf("Unexpected metric names : %v", names)
+	}
+
+	dataPoints := metrics[0].GetSum().DataPoints
+	if len(dataPoints) != 1 {
+		t.Errorf("Expected the values to be added up into 1 data point, but received : %d", len(dataPoints))
+		return
+	}
+	attributes := otlpAttributes(dataPoints[0].Attributes)
+	if fmt.Sprint(attributes) != "map[destinationComponent:hr]" {
+		t.Errorf("Unexpected data point attributes : %v", attributes)
+	}
+	if dataPoints[0].GetAsInt() != 3072 {
+		t.Errorf("Unexpected data point : %v", dataPoints[0])
+	}
+	firstExport := dataPoints[0].TimeUnixNano
+
+	dataPoints = metrics[1].GetSum().DataPoints
+	if dataPoints[0].GetAsInt() != 300 {
+		t.Errorf("Unexpected data point value : %v", dataPoints[0].Value)
+	}
+
+	dataPoints = metrics[2].GetSum().DataPoints
+	if dataPoints[0].GetAsDouble() != 1.5 {
+		t.Errorf("Unexpected data point value : %v", dataPoints[0].Value)
+	}
+
+	dataPoints = metrics[3].GetSum().DataPoints
+	attributes = otlpAttributes(dataPoints[0].Attributes)
+	if fmt.Sprint(attributes) != "map[responseCode:200]" {
+		t.Errorf("Unexpected data point attributes : %v", attributes)
+	}
+	if dataPoints[0].GetAsInt() != 1 {
+		t.Errorf("Unexpected data point value : %v", dataPoints[0].Value)
+	}
+
+	// The next data points of the same streams start when the previous ones ended
+	err = exporter.Export(testBatchID, batch)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(receiver.requests) != 2 {
+		t.Errorf("Expected a second request, but received : %d", len(receiver.requests))
+		return
+	}
+	request = &collectormetricspb.ExportMetricsServiceRequest{}
+	err = proto.Unmarshal(receiver.requests[1].gunzip(t), request)
+	if err != nil {
+		t.Errorf("Error when unmarshalling the request : %v", err)
+		return
+	}
+	dataPoint := request.ResourceMetrics[0].ScopeMetrics[0].Metrics[0].GetSum().DataPoints[0]
+	if dataPoint.StartTimeUnixNano != firstExport || dataPoint.TimeUnixNano <= firstExport {
+		t.Errorf("Expected the data point to start at %d, but received : %v", firstExport, dataPoint)
+	}
+}
+
+func TestOtlpExportWithInvalidSpan(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(nil)
+	defer receiver.server.Close()
+	exporter := NewOtlpExporter(&Otlp{Endpoint: receiver.server.URL}, codec.SpanSchema, &http.Client{}, logger)
+	err = exporter.Export(testBatchID, "[{\"traceId\":\"xyz\",\"id\":\"1\"}]")
+	if _, ok := err.(*PermanentError); !ok {
+		t.Errorf("Expected a permanent error, but received : %v", err)
+	}
+	if len(receiver.requests) != 0 {
+		t.Errorf("Invalid spans should not be sent, but sent %d requests", len(receiver.requests))
+	}
+}
+
+func TestFetchWithExporters(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	spRequests := 0
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		spRequests++
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	receiver := newTestReceiver(respondWith(503))
+	defer receiver.server.Close()
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"requestPath\":\"/pets\"}]"},
+	}
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   persister,
+		Exporters: []Exporter{NewOtlpExporter(&Otlp{Endpoint: receiver.server.URL}, codec.TelemetrySchema,
+			&http.Client{}, logger)},
+	}
+	err = publisher.execute()
+	expectedErr := "failed to publish the metrics : otlp : received a bad response code from the server, " +
+		"received response code : 503"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+	if persister.rolledBack != 1 {
+		t.Errorf("Expected the batch to be rolled back, rolled back : %d", persister.rolledBack)
+	}
+
+	// The batch is not sent to SP again, since SP already accepted it
+	persister.batches = []string{"[{\"requestPath\":\"/pets\"}]"}
+	err = publisher.execute()
+	if err == nil {
+		t.Error("An error was not thrown, but expected one from the exporter")
+	}
+	if spRequests != 1 || len(receiver.requests) != 2 {
+		t.Errorf("Unexpected number of requests, SP : %d, OTLP : %d", spRequests, len(receiver.requests))
+	}
+}
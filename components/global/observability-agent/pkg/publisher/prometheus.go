@@ -0,0 +1,439 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPrometheusMetricName  = "cellery_requests"
+	defaultPrometheusNameField   = instanceNameField
+	defaultPrometheusValueField  = instanceValueField
+	defaultPrometheusMaxSeries   = 10000
+	defaultPrometheusSeriesTTL   = time.Hour
+	defaultPrometheusLabelLength = 256
+	prometheusMetricNameLabel    = "__name__"
+)
+
+type (
+	// PrometheusRemoteWrite is the configuration of the Prometheus remote-write exporter
+	PrometheusRemoteWrite struct {
+		// Endpoint is the full URL of the remote-write receiver, such as http://prometheus:9090/api/v1/write
+		Endpoint string            `json:"endpoint"`
+		Headers  map[string]string `json:"headers"`
+		// MetricName is used for the records which do not carry the name of the metric instance
+		MetricName string `json:"metricName"`
+		// NameField and ValueField are the fields of a record holding the metric instance name and value
+		NameField  string `json:"nameField"`
+		ValueField string `json:"valueField"`
+		// Labels maps the dimensions to label names. When it is empty, the source and destination instances and
+		// components, the request method and the response code become labels.
+		Labels       map[string]string `json:"labels"`
+		StaticLabels map[string]string `json:"staticLabels"`
+		// Measurements are the dimensions added up into <metric>_<measurement>_sum instead of becoming labels. When it
+		// is empty, the response duration and the request and response sizes are used.
+		Measurements []string `json:"measurements"`
+		// MaxSeries and MaxLabelValueLength guard Prometheus against dimensions with a high cardinality
+		MaxSeries           int `json:"maxSeries"`
+		MaxLabelValueLength int `json:"maxLabelValueLength"`
+		// SeriesTTLSeconds is the time after which a series without any new records is no longer sent, freeing its
+		// place under MaxSeries. A series which receives records again starts counting from zero, which Prometheus
+		// treats as a counter reset.
+		SeriesTTLSeconds int `json:"seriesTtlSeconds"`
+	}
+
+	// PrometheusExporter converts the metric records into cumulative Prometheus counters and sends them using the
+	// remote-write protocol. For each label set, <metric>_total counts the records, <metric>_sum adds up the values of
+	// the records and <metric>_<measurement>_sum adds up each measurement of the records.
+	PrometheusExporter struct {
+		Endpoint            string
+		Headers             map[string]string
+		MetricName          string
+		NameField           string
+		ValueField          string
+		Labels              map[string]string
+		StaticLabels        map[string]string
+		Measurements        []string
+		MaxSeries           int
+		MaxLabelValueLength int
+		SeriesTTL           time.Duration
+		HttpClient          *http.Client
+		Logger              *zap.SugaredLogger
+		Classifier          ResponseClassifier
+
+		lock    sync.Mutex
+		series  map[string]*prometheusSeries
+		dropped int64
+	}
+
+	prometheusSeries struct {
+		name   string
+		labels []prometheusLabel
+		count  float64
+		sum    float64
+		hasSum bool
+		// measurements are the sums of the measurements by the dimension
+		measurements map[string]float64
+		updated      time.Time
+	}
+
+	prometheusLabel struct {
+		name  string
+		value string
+	}
+)
+
+func NewPrometheusExporter(config *PrometheusRemoteWrite, httpClient *http.Client,
+	logger *zap.SugaredLogger) *PrometheusExporter {
+	exporter := &PrometheusExporter{
+		Endpoint:            config.Endpoint,
+		Headers:             config.Headers,
+		MetricName:          config.MetricName,
+		NameField:           config.NameField,
+		ValueField:          config.ValueField,
+		Labels:              config.Labels,
+		StaticLabels:        config.StaticLabels,
+		Measurements:        config.Measurements,
+		MaxSeries:           config.MaxSeries,
+		MaxLabelValueLength: config.MaxLabelValueLength,
+		SeriesTTL:           time.Duration(config.SeriesTTLSeconds) * time.Second,
+		HttpClient:          httpClient,
+		Logger:              logger,
+	}
+	if exporter.MetricName == "" {
+		exporter.MetricName = defaultPrometheusMetricName
+	}
+	if exporter.NameField == "" {
+		exporter.NameField = defaultPrometheusNameField
+	}
+	if exporter.ValueField == "" {
+		exporter.ValueField = defaultPrometheusValueField
+	}
+	if len(exporter.Labels) == 0 {
+		exporter.Labels = make(map[string]string, len(defaultMetricDimensions))
+		for _, dimension := range defaultMetricDimensions {
+			exporter.Labels[dimension] = dimension
+		}
+	}
+	if len(exporter.Measurements) == 0 {
+		exporter.Measurements = defaultMeasurements
+	}
+	if exporter.MaxSeries <= 0 {
+		exporter.MaxSeries = defaultPrometheusMaxSeries
+	}
+	if exporter.MaxLabelValueLength <= 0 {
+		exporter.MaxLabelValueLength = defaultPrometheusLabelLength
+	}
+	if exporter.SeriesTTL <= 0 {
+		exporter.SeriesTTL = defaultPrometheusSeriesTTL
+	}
+	return exporter
+}
+
+func (exporter *PrometheusExporter) Name() string {
+	return "prometheus"
+}
+
+// Export adds the records to the counters and sends the updated series. The counters are only updated after the
+// receiver accepts them, so that a batch which is retried is not counted twice.
+func (exporter *PrometheusExporter) Export(id string, jsonArr string) error {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	if exporter.series == nil {
+		exporter.series = make(map[string]*prometheusSeries)
+	}
+	decoder := json.NewDecoder(strings.NewReader(jsonArr))
+	decoder.UseNumber()
+	var records []map[string]interface{}
+	err := decoder.Decode(&records)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not unmarshal the records : %v", err)}
+	}
+	now := time.Now()
+	exporter.expireSeries(now)
+	updated := make(map[string]*prometheusSeries)
+	dropped := 0
+	for _, record := range records {
+		name, labels := exporter.seriesOf(record)
+		key := seriesKey(name, labels)
+		series, ok := updated[key]
+		if !ok {
+			if current, ok := exporter.series[key]; ok {
+				series = current.copy()
+			} else if len(exporter.series)+len(updated) >= exporter.MaxSeries {
+				dropped++
+				continue
+			} else {
+				series = &prometheusSeries{name: name, labels: labels, measurements: make(map[string]float64)}
+			}
+			updated[key] = series
+		}
+		series.count++
+		series.updated = now
+		if value, ok := toFloat(record[exporter.ValueField]); ok {
+			series.sum += value
+			series.hasSum = true
+		}
+		for _, measurement := range exporter.Measurements {
+			if value, ok := toFloat(record[measurement]); ok {
+				series.measurements[measurement] += value
+			}
+		}
+	}
+	if len(updated) > 0 {
+		err = exporter.send(toWriteRequest(updated, now))
+		if err != nil {
+			return err
+		}
+	}
+	for key, series := range updated {
+		exporter.series[key] = series
+	}
+	// The dropped records are only counted once the batch is exported, so that a retried batch is not counted twice
+	if dropped > 0 {
+		exporter.dropped += int64(dropped)
+		exporter.Logger.Warnf("Dropped %d records since the number of series exceeded the limit of %d, %d records "+
+			"dropped so far", dropped, exporter.MaxSeries, exporter.dropped)
+	}
+	return nil
+}
+
+// Dropped returns the number of records dropped since the number of series exceeded the limit
+func (exporter *PrometheusExporter) Dropped() int64 {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	return exporter.dropped
+}
+
+func (series *prometheusSeries) copy() *prometheusSeries {
+	copied := *series
+	copied.measurements = make(map[string]float64, len(series.measurements))
+	for measurement, value := range series.measurements {
+		copied.measurements[measurement] = value
+	}
+	return &copied
+}
+
+// expireSeries removes the series which have not received any records within the TTL
+func (exporter *PrometheusExporter) expireSeries(now time.Time) {
+	expired := 0
+	for key, series := range exporter.series {
+		if now.Sub(series.updated) > exporter.SeriesTTL {
+			delete(exporter.series, key)
+			expired++
+		}
+	}
+	if expired > 0 {
+		exporter.Logger.Debugf("Expired %d series which did not receive any records within %s", expired,
+			exporter.SeriesTTL)
+	}
+}
+
+func (exporter *PrometheusExporter) send(request *prompb.WriteRequest) error {
+	bytesArr, err := proto.Marshal(request)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not marshal the write request : %v", err)}
+	}
+	req, err := http.NewRequest("POST", exporter.Endpoint, bytes.NewReader(snappy.Encode(nil, bytesArr)))
+	if err != nil {
+		return fmt.Errorf("could not make a new request : %v", err)
+	}
+	for key, value := range exporter.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	return doRequest(exporter.HttpClient, req, exporter.Classifier)
+}
+
+// seriesOf returns the metric name and the sorted labels of the series the record belongs to
+func (exporter *PrometheusExporter) seriesOf(record map[string]interface{}) (string, []prometheusLabel) {
+	name := exporter.MetricName
+	if instanceName, ok := record[exporter.NameField].(string); ok && instanceName != "" {
+		name = instanceName
+	}
+	labelsByName := make(map[string]string)
+	for dimension, value := range record {
+		labelName, ok := exporter.Labels[dimension]
+		if !ok || dimension == exporter.NameField || dimension == exporter.ValueField {
+			continue
+		}
+		labelValue := labelValueOf(value)
+		if labelValue == "" {
+			continue
+		}
+		if len(labelValue) > exporter.MaxLabelValueLength {
+			labelValue = labelValue[:exporter.MaxLabelValueLength]
+		}
+		labelName = sanitizeLabelName(labelName)
+		// Label names starting with __ are reserved for Prometheus
+		if strings.HasPrefix(labelName, "__") {
+			continue
+		}
+		labelsByName[labelName] = labelValue
+	}
+	for labelName, value := range exporter.StaticLabels {
+		labelsByName[sanitizeLabelName(labelName)] = value
+	}
+	labels := make([]prometheusLabel, 0, len(labelsByName))
+	for _, labelName := range sortedStringKeys(labelsByName) {
+		labels = append(labels, prometheusLabel{name: labelName, value: labelsByName[labelName]})
+	}
+	return sanitizeMetricName(name), labels
+}
+
+// toWriteRequest converts the series into a remote-write WriteRequest
+func toWriteRequest(series map[string]*prometheusSeries, now time.Time) *prompb.WriteRequest {
+	timestamp := now.UnixNano() / int64(time.Millisecond)
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	request := &prompb.WriteRequest{}
+	for _, key := range keys {
+		s := series[key]
+		request.Timeseries = append(request.Timeseries, toTimeSeries(s.name+"_total", s.labels, s.count, timestamp))
+		if s.hasSum {
+			request.Timeseries = append(request.Timeseries, toTimeSeries(s.name+"_sum", s.labels, s.sum, timestamp))
+		}
+		for _, measurement := range sortedFloatKeys(s.measurements) {
+			request.Timeseries = append(request.Timeseries, toTimeSeries(sanitizeMetricName(s.name+"_"+measurement+
+				"_sum"), s.labels, s.measurements[measurement], timestamp))
+		}
+	}
+	return request
+}
+
+func toTimeSeries(name string, labels []prometheusLabel, value float64, timestamp int64) *prompb.TimeSeries {
+	timeSeries := &prompb.TimeSeries{
+		Labels:  make([]*prompb.Label, 0, len(labels)+1),
+		Samples: []prompb.Sample{{Value: value, Timestamp: timestamp}},
+	}
+	timeSeries.Labels = append(timeSeries.Labels, &prompb.Label{Name: prometheusMetricNameLabel, Value: name})
+	for _, label := range labels {
+		timeSeries.Labels = append(timeSeries.Labels, &prompb.Label{Name: label.name, Value: label.value})
+	}
+	// The labels of a series are expected to be sorted by the name, including __name__, which sorts after the
+	// label names starting with an uppercase letter
+	sort.Slice(timeSeries.Labels, func(i, j int) bool {
+		return timeSeries.Labels[i].Name < timeSeries.Labels[j].Name
+	})
+	return timeSeries
+}
+
+func sortedFloatKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func seriesKey(name string, labels []prometheusLabel) string {
+	var builder strings.Builder
+	builder.WriteString(name)
+	for _, label := range labels {
+		builder.WriteString("\xff")
+		builder.WriteString(label.name)
+		builder.WriteString("=")
+		builder.WriteString(label.value)
+	}
+	return builder.String()
+}
+
+func labelValueOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprintf("%t", v)
+	default:
+		str, _ := json.Marshal(v)
+		return string(str)
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil || math.IsNaN(f) {
+			return 0, false
+		}
+		return f, true
+	case float64:
+		return v, true
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// sanitizeMetricName replaces the characters which are not allowed in a Prometheus metric name with underscores
+func sanitizeMetricName(name string) string {
+	return sanitizeName(name, true)
+}
+
+// sanitizeLabelName replaces the characters which are not allowed in a Prometheus label name with underscores
+func sanitizeLabelName(name string) string {
+	return sanitizeName(name, false)
+}
+
+func sanitizeName(name string, allowColon bool) string {
+	var builder strings.Builder
+	for i, c := range name {
+		valid := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (allowColon && c == ':') ||
+			(i > 0 && c >= '0' && c <= '9')
+		if i == 0 && c >= '0' && c <= '9' {
+			builder.WriteRune('_')
+			valid = true
+		}
+		if valid {
+			builder.WriteRune(c)
+		} else {
+			builder.WriteRune('_')
+		}
+	}
+	return builder.String()
+}
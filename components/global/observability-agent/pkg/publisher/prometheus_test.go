@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+)
+
+// remoteWriteSeries returns the latest value of each series written by the remote-write requests which were
+// accepted by the receiver
+func remoteWriteSeries(t *testing.T, receiver *testReceiver) map[string]float64 {
+	series := make(map[string]float64)
+	for _, req := range receiver.requests {
+		if req.header.Get("Content-Encoding") != "snappy" ||
+			req.header.Get("X-Prometheus-Remote-Write-Version") != "0.1.0" {
+			t.Errorf("Unexpected headers : %v", req.header)
+		}
+		if req.statusCode >= 300 {
+			continue
+		}
+		bytesArr, err := snappy.Decode(nil, req.body)
+		if err != nil {
+			t.Errorf("Error when decoding snappy : %v", err)
+		}
+		request := &prompb.WriteRequest{}
+		err = proto.Unmarshal(bytesArr, request)
+		if err != nil {
+			t.Errorf("Error when unmarshalling the write request : %v", err)
+		}
+		for _, timeSeries := range request.Timeseries {
+			var labels []string
+			for _, label := range timeSeries.Labels {
+				labels = append(labels, label.Name+"="+label.Value)
+			}
+			if len(timeSeries.Samples) != 1 {
+				t.Errorf("Expected 1 sample, but received : %v", timeSeries.Samples)
+				continue
+			}
+			series[strings.Join(labels, ",")] = timeSeries.Samples[0].Value
+			if timeSeries.Samples[0].Timestamp == 0 {
+				t.Error("Expected the sample to have a timestamp")
+			}
+		}
+	}
+	return series
+}
+
+func TestPrometheusExport(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondWith(204))
+	defer receiver.server.Close()
+	exporter := NewPrometheusExporter(&PrometheusRemoteWrite{
+		Endpoint:     receiver.server.URL,
+		Labels:       map[string]string{"responseCode": "response_code", "destination.cell": "cell"},
+		StaticLabels: map[string]string{"cluster": "dev"},
+	}, &http.Client{}, logger)
+	batch := "[{\"instanceName\":\"request-size\",\"value\":100,\"responseCode\":200," +
+		"\"destination.cell\":\"pet-be\",\"requestPath\":\"/pets\"},{\"instanceName\":\"request-size\"," +
+		"\"value\":50,\"responseCode\":200,\"destination.cell\":\"pet-be\"},{\"responseCode\":500}]"
+	err = exporter.Export(testBatchID, batch)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	batch = "[{\"instanceName\":\"request-size\",\"value\":25.5,\"responseCode\":200," +
+		"\"destination.cell\":\"pet-be\"}]"
+	err = exporter.Export(testBatchID, batch)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	expected := map[string]float64{
+		"__name__=request_size_total,cell=pet-be,cluster=dev,response_code=200": 3,
+		"__name__=request_size_sum,cell=pet-be,cluster=dev,response_code=200":   175.5,
+		"__name__=cellery_requests_total,cluster=dev,response_code=500":         1,
+	}
+	series := remoteWriteSeries(t, receiver)
+	if len(series) != len(expected) {
+		t.Errorf("Unexpected series : %v", series)
+	}
+	for name, value := range expected {
+		if series[name] != value {
+			t.Errorf("Expected %s to be %f, but received : %f", name, value, series[name])
+		}
+	}
+}
+
+func TestPrometheusExportWithMaxSeries(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondWith(204))
+	defer receiver.server.Close()
+	exporter := NewPrometheusExporter(&PrometheusRemoteWrite{
+		Endpoint:            receiver.server.URL,
+		MaxSeries:           1,
+		MaxLabelValueLength: 4,
+	}, &http.Client{}, logger)
+	batch := "[{\"destinationComponent\":\"pets-1\"},{\"destinationComponent\":\"pets-2\"}," +
+		"{\"destinationComponent\":\"hr\"},{\"destinationComponent\":\"hr\"}]"
+	err = exporter.Export(testBatchID, batch)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	series := remoteWriteSeries(t, receiver)
+	// The first two components are the same after truncating them, and the last component exceeds the series limit
+	if len(series) != 1 || series["__name__=cellery_requests_total,destinationComponent=pets"] != 2 {
+		t.Errorf("Unexpected series : %v", series)
+	}
+	if exporter.Dropped() != 2 {
+		t.Errorf("Expected 2 dropped records, but received : %d", exporter.Dropped())
+	}
+}
+
+func TestPrometheusExportWithFailure(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondInTurn(503, 204))
+	defer receiver.server.Close()
+	exporter := NewPrometheusExporter(&PrometheusRemoteWrite{Endpoint: receiver.server.URL}, &http.Client{},
+		logger)
+	jsonArr := "[{\"responseCode\":200}]"
+	err = exporter.Export(testBatchID, jsonArr)
+	if _, ok := err.(*RetryableError); !ok {
+		t.Errorf("Expected a retryable error, but received : %v", err)
+	}
+	// The retried batch should only be counted once
+	err = exporter.Export(testBatchID, jsonArr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	series := remoteWriteSeries(t, receiver)
+	if len(receiver.requests) != 2 || series["__name__=cellery_requests_total,responseCode=200"] != 1 {
+		t.Errorf("Unexpected series : %v", series)
+	}
+}
+
+func TestPrometheusExportWithoutLabels(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondWith(204))
+	defer receiver.server.Close()
+	exporter := NewPrometheusExporter(&PrometheusRemoteWrite{
+		Endpoint:     receiver.server.URL,
+		StaticLabels: map[string]string{"Region": "us"},
+	}, &http.Client{}, logger)
+	batch := "[{\"requestId\":\"1\",\"traceId\":\"a\",\"requestPath\":\"/pets/1\"," +
+		"\"sourcePod\":\"hr-1\",\"destinationComponent\":\"pets\",\"responseCode\":200," +
+		"\"responseDurationNanoSec\":1500,\"requestSizeBytes\":10},{\"requestId\":\"2\",\"traceId\":\"b\"," +
+		"\"requestPath\":\"/pets/2\",\"sourcePod\":\"hr-2\",\"destinationComponent\":\"pets\"," +
+		"\"responseCode\":200,\"responseDurationNanoSec\":500,\"requestSizeBytes\":20}]"
+	err = exporter.Export(testBatchID, batch)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	// Only the low cardinality dimensions become labels, the measurements are added up, and __name__ sorts after the
+	// uppercase label names
+	labels := ",destinationComponent=pets,responseCode=200"
+	expected := map[string]float64{
+		"Region=us,__name__=cellery_requests_total" + labels:                       2,
+		"Region=us,__name__=cellery_requests_responseDurationNanoSec_sum" + labels: 2000,
+		"Region=us,__name__=cellery_requests_requestSizeBytes_sum" + labels:        30,
+	}
+	series := remoteWriteSeries(t, receiver)
+	if len(series) != len(expected) {
+		t.Errorf("Unexpected series : %v", series)
+	}
+	for name, value := range expected {
+		if series[name] != value {
+			t.Errorf("Expected %s to be %f, but received : %f", name, value, series[name])
+		}
+	}
+}
+
+func TestPrometheusExportWithExpiredSeries(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondWith(204))
+	defer receiver.server.Close()
+	exporter := NewPrometheusExporter(&PrometheusRemoteWrite{
+		Endpoint:  receiver.server.URL,
+		MaxSeries: 1,
+	}, &http.Client{}, logger)
+	err = exporter.Export(testBatchID, "[{\"destinationComponent\":\"pets\"}]")
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	for _, series := range exporter.series {
+		series.updated = series.updated.Add(-exporter.SeriesTTL - time.Second)
+	}
+	// The stale series should make way for the new series instead of the new series being dropped
+	err = exporter.Export(testBatchID, "[{\"destinationComponent\":\"owners\"},{\"destinationComponent\":\"pets\"}]")
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	series := remoteWriteSeries(t, receiver)
+	if len(exporter.series) != 1 ||
+		series["__name__=cellery_requests_total,destinationComponent=owners"] != 1 ||
+		series["__name__=cellery_requests_total,destinationComponent=pets"] != 1 {
+		t.Errorf("Unexpected series : %v", series)
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"request.size":   "request_size",
+		"2xx-responses":  "_2xx_responses",
+		"cellery:req_ok": "cellery:req_ok",
+	}
+	for name, expected := range tests {
+		if sanitized := sanitizeMetricName(name); sanitized != expected {
+			t.Errorf("Expected %s to be sanitized to %s, but received : %s", name, expected, sanitized)
+		}
+	}
+	if sanitized := sanitizeLabelName("cellery:cell"); sanitized != "cellery_cell" {
+		t.Errorf("Expected cellery_cell, but received : %s", sanitized)
+	}
+}
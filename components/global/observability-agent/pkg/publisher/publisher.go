@@ -21,103 +21,619 @@ package publisher
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store"
 )
 
+const (
+	defaultSendInterval    = 60 * time.Second
+	statsReportInterval    = 5 * time.Minute
+	defaultSignatureHeader = "X-Cellery-Signature"
+	idempotencyKeyHeader   = "Idempotency-Key"
+)
+
 type (
+	// Publisher drains the batches from the store and publishes them to SP. Up to Workers goroutines publish
+	// batches concurrently, with at most MaxInFlightRequests requests in flight. If PreserveOrder is set and the
+	// persister returns the batches in the order they were written, the batches are published one at a time.
+	// The store is drained as soon as a new batch is signalled through Notify, and every SendInterval otherwise.
+	// Each request carries the ID of the batch as the Idempotency-Key header, and if SigningSecret is set, an
+	// HMAC-SHA256 signature of the body in the SignatureHeader. If Streaming is set, the batches are streamed into
+	// the requests instead of being encoded in memory, with at most MaxRequestBytes of records in a request, and
+	// read straight from the store if the persister supports it. RecordsPerSecond and BytesPerSecond limit the
+	// records and the JSON bytes of the batches, which wait for the limits once before they are published.
 	Publisher struct {
-		Ticker      *time.Ticker
-		Logger      *zap.SugaredLogger
-		SpServerUrl string
-		HttpClient  *http.Client
-		Persister   store.Persister
+		SendInterval        time.Duration
+		Notify              <-chan struct{}
+		Logger              *zap.SugaredLogger
+		SpServerUrl         string
+		HttpClient          *http.Client
+		Persister           store.Persister
+		Exporters           []Exporter
+		Classifier          ResponseClassifier
+		MaxPayloadBytes     int
+		Codec               codec.Codec
+		Compressor          Compressor
+		Workers             int
+		MaxInFlightRequests int
+		PreserveOrder       bool
+		RecordsPerSecond    int
+		BytesPerSecond      int
+		SigningSecret       string
+		SignatureHeader     string
+		Streaming           bool
+		MaxRequestBytes     int
+		stats               Statistics
+		reportedStats       Statistics
+		lock                sync.Mutex
+		retryAt             time.Time
+		inFlight            chan struct{}
+		initOnce            sync.Once
+		recordsLimiter      *rateLimiter
+		bytesLimiter        *rateLimiter
+		exported            map[string]map[string]bool
+	}
+
+	// drainRound is shared by the workers draining the store in a single execution
+	drainRound struct {
+		orderLock sync.Mutex
+		lock      sync.Mutex
+		stopped   bool
+	}
+
+	// Statistics holds the number of batches handled by the publisher. Retried batches are kept in the store and
+	// sent again later, while dropped batches were rejected permanently by the server and removed from the store.
+	// Throttled is the total time the batches waited because of the configured rate limits.
+	Statistics struct {
+		Published uint64
+		Retried   uint64
+		Dropped   uint64
+		Throttled time.Duration
 	}
 
 	SpEndpoint struct {
 		URL                 string `json:"url"`
 		SendIntervalSeconds int    `json:"sendIntervalSeconds"`
+		MaxPayloadBytes     int    `json:"maxPayloadBytes"`
+		Encoding            string `json:"encoding"`
+		Compression         string `json:"compression"`
+		CompressionLevel    int    `json:"compressionLevel"`
+		Workers             int    `json:"workers"`
+		MaxInFlightRequests int    `json:"maxInFlightRequests"`
+		PreserveOrder       bool   `json:"preserveOrder"`
+		RecordsPerSecond    int    `json:"recordsPerSecond"`
+		BytesPerSecond      int    `json:"bytesPerSecond"`
+		SigningSecret       string `json:"signingSecret"`
+		SignatureHeader     string `json:"signatureHeader"`
+		Streaming           bool   `json:"streaming"`
+		MaxRequestBytes     int    `json:"maxRequestBytes"`
 	}
 )
 
 func (publisher *Publisher) Run(stopCh <-chan struct{}) {
 	publisher.Logger.Info("Publisher started")
+	if publisher.PreserveOrder && !publisher.ordered() {
+		publisher.Logger.Warn("The persister does not keep the order of the batches, hence the order of the " +
+			"published batches is not preserved")
+	}
+	if _, ok := publisher.codec().(codec.StreamingCodec); publisher.Streaming && !ok {
+		publisher.Logger.Warn("The encoding does not support streaming, hence the batches are published without " +
+			"streaming")
+	}
+	interval := publisher.SendInterval
+	if interval <= 0 {
+		interval = defaultSendInterval
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	statsTicker := time.NewTicker(statsReportInterval)
+	defer statsTicker.Stop()
+	// notifyCh is nil while waiting to retry after a failure, so that the batches persisted in the meantime do not
+	// keep hitting the failing server
+	notifyCh := publisher.Notify
 	for {
 		select {
 		case <-stopCh:
+			publisher.reportStats()
 			return
-		case <-publisher.Ticker.C:
-			err := publisher.execute()
-			if err != nil {
-				publisher.Logger.Errorf("Error when executing : %v", err)
-			}
+		case <-statsTicker.C:
+			publisher.reportStats()
+			continue
+		case <-notifyCh:
+		case <-timer.C:
+		}
+		if wait := publisher.retryWait(); wait > 0 {
+			publisher.Logger.Debugf("Server asked to retry later, skipping publishing for another %s", wait)
+			notifyCh = nil
+			resetTimer(timer, wait)
+			continue
+		}
+		err := publisher.execute()
+		if err != nil {
+			publisher.Logger.Errorf("Error when executing : %v", err)
+			notifyCh = nil
+		} else {
+			notifyCh = publisher.Notify
+		}
+		// The store is either drained or the server is failing, hence wait for the next batch or the interval
+		resetTimer(timer, interval)
+	}
+}
+
+func resetTimer(timer *time.Timer, duration time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
 		}
 	}
+	timer.Reset(duration)
 }
 
 func (publisher *Publisher) execute() error {
-	for {
-		str, transaction, err := publisher.Persister.Fetch()
+	publisher.init()
+	round := &drainRound{}
+	workers := publisher.Workers
+	if workers <= 1 {
+		return publisher.drain(round)
+	}
+	errs := make(chan error, workers)
+	var waitGroup sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			errs <- publisher.drain(round)
+		}()
+	}
+	waitGroup.Wait()
+	close(errs)
+	for err := range errs {
 		if err != nil {
-			rollbackErr := transaction.Rollback()
-			if rollbackErr != nil {
-				publisher.Logger.Debugf("Could not rollback the transaction : %v", rollbackErr)
-			}
-			return fmt.Errorf("failed to fetch the metrics : %v", err)
+			return err
 		}
-		if str != "" {
-			err = publisher.publish(str)
-			if err != nil {
-				rollbackErr := transaction.Rollback()
-				if rollbackErr != nil {
-					publisher.Logger.Errorf("Failed to rollback the transaction : %v", rollbackErr)
-				}
-				return fmt.Errorf("failed to publish the metrics : %v", err)
-			} else {
-				err = transaction.Commit()
-				if err != nil {
-					publisher.Logger.Errorf("Failed to commit the transaction : %v", err)
-				}
-			}
-		} else {
-			err = transaction.Rollback()
+	}
+	return nil
+}
+
+// drain keeps publishing batches from the store until the store is empty or a batch could not be published
+func (publisher *Publisher) drain(round *drainRound) error {
+	ordered := publisher.PreserveOrder && publisher.ordered()
+	for !round.isStopped() {
+		if ordered {
+			round.orderLock.Lock()
+		}
+		done, err := publisher.publishNext()
+		if ordered {
+			round.orderLock.Unlock()
+		}
+		if done || err != nil {
+			// Other workers need not continue either, since the store is either empty or the server is failing
+			round.stop()
+			return err
+		}
+	}
+	return nil
+}
+
+// publishNext fetches a single batch from the store and publishes it. It returns true if the store is empty.
+func (publisher *Publisher) publishNext() (bool, error) {
+	if persister, ok := publisher.readerPersister(); ok {
+		return publisher.streamNext(persister)
+	}
+	str, transaction, err := publisher.Persister.Fetch()
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			publisher.Logger.Debugf("Could not rollback the transaction : %v", rollbackErr)
+		}
+		return false, fmt.Errorf("failed to fetch the metrics : %v", err)
+	}
+	if str == "" {
+		err = transaction.Rollback()
+		if err != nil {
+			publisher.Logger.Debugf("Could not rollback the transaction : %v", err)
+		}
+		return true, nil
+	}
+	id := transaction.ID()
+	return false, publisher.complete(id, transaction, publisher.export(id, str))
+}
+
+// streamNext fetches a single batch from the store as a reader and streams it to SP, so that the batch is not held
+// in memory. It returns true if the store is empty.
+func (publisher *Publisher) streamNext(persister store.ReaderPersister) (bool, error) {
+	batch, transaction, err := persister.FetchReader()
+	if err != nil {
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			publisher.Logger.Debugf("Could not rollback the transaction : %v", rollbackErr)
+		}
+		return false, fmt.Errorf("failed to fetch the metrics : %v", err)
+	}
+	if batch == nil {
+		err = transaction.Rollback()
+		if err != nil {
+			publisher.Logger.Debugf("Could not rollback the transaction : %v", err)
+		}
+		return true, nil
+	}
+	id := transaction.ID()
+	err = publisher.streamFrom(id, batch)
+	closeErr := batch.Close()
+	if closeErr != nil {
+		publisher.Logger.Debugf("Could not close the batch : %v", closeErr)
+	}
+	return false, publisher.complete(id, transaction, err)
+}
+
+// complete commits the transaction of a batch which was published or rejected permanently, and rolls it back
+// otherwise
+func (publisher *Publisher) complete(id string, transaction store.Transaction, err error) error {
+	if err != nil {
+		if permanentErr, ok := err.(*PermanentError); ok {
+			// Sending the same batch again would be rejected as well, hence it is removed from the store
+			publisher.Logger.Errorf("Dropping the batch since the server rejected it : %v", permanentErr)
+			publisher.count(func(stats *Statistics) { stats.Dropped++ })
+			err = transaction.Commit()
 			if err != nil {
-				publisher.Logger.Debugf("Could not rollback the transaction : %v", err)
+				publisher.Logger.Errorf("Failed to commit the transaction : %v", err)
 			}
+			publisher.forget(id)
 			return nil
 		}
+		rollbackErr := transaction.Rollback()
+		if rollbackErr != nil {
+			publisher.Logger.Errorf("Failed to rollback the transaction : %v", rollbackErr)
+		}
+		publisher.count(func(stats *Statistics) { stats.Retried++ })
+		cause := err
+		if destErr, ok := err.(*destinationError); ok {
+			cause = destErr.err
+		}
+		if retryableErr, ok := cause.(*RetryableError); ok && retryableErr.RetryAfter > 0 {
+			publisher.backOff(retryableErr.RetryAfter)
+		}
+		return fmt.Errorf("failed to publish the metrics : %v", err)
+	}
+	publisher.count(func(stats *Statistics) { stats.Published++ })
+	err = transaction.Commit()
+	if err != nil {
+		publisher.Logger.Errorf("Failed to commit the transaction : %v", err)
 	}
+	publisher.forget(id)
+	return nil
+}
+
+// publish waits for the rate limits once for the whole batch and publishes it. The records are not throttled again
+// when they are sent after splitting the batch or falling back to gzip.
+func (publisher *Publisher) publish(id string, jsonArr string) error {
+	publisher.throttle(strings.NewReader(jsonArr))
+	return publisher.publishBatch(id, jsonArr)
 }
 
-func (publisher *Publisher) publish(jsonArr string) error {
+// publishBatch encodes and sends the batch with the given ID as the idempotency key
+func (publisher *Publisher) publishBatch(id string, jsonArr string) error {
 	var buf bytes.Buffer
-	g := gzip.NewWriter(&buf)
-	if _, err := g.Write([]byte(jsonArr)); err != nil {
-		return fmt.Errorf("could not write to buffer : %v", err)
+	compressor := publisher.compressor()
+	w, err := compressor.NewWriter(&buf)
+	if err != nil {
+		return fmt.Errorf("could not create the compressor : %v", err)
+	}
+	if err := publisher.codec().Encode(w, jsonArr); err != nil {
+		// The batch would never be encoded successfully, hence it should not be retried
+		return &PermanentError{Reason: fmt.Sprintf("could not encode the batch : %v", err)}
 	}
-	if err := g.Close(); err != nil {
-		return fmt.Errorf("could not close the gzip writer : %v", err)
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("could not close the compressor : %v", err)
 	}
-	req, err := http.NewRequest("POST", publisher.SpServerUrl, &buf)
+	if publisher.MaxPayloadBytes > 0 && buf.Len() > publisher.MaxPayloadBytes {
+		publisher.Logger.Debugf("Payload of %d bytes exceeds the limit of %d bytes, splitting the batch", buf.Len(),
+			publisher.MaxPayloadBytes)
+		return publisher.publishSplit(id, jsonArr)
+	}
+	err = publisher.send(&buf, compressor.ContentEncoding(), id)
+	if permanentErr, ok := err.(*PermanentError); ok {
+		switch {
+		case permanentErr.StatusCode == http.StatusRequestEntityTooLarge:
+			publisher.Logger.Debugf("Server rejected a payload of %d bytes as too large, splitting the batch",
+				len(jsonArr))
+			return publisher.publishSplit(id, jsonArr)
+		case permanentErr.StatusCode == http.StatusUnsupportedMediaType &&
+			compressor.ContentEncoding() != GzipCompression:
+			publisher.Logger.Warnf("Server does not support %s compression, falling back to gzip",
+				compressor.ContentEncoding())
+			publisher.fallbackToGzip()
+			return publisher.publishBatch(id, jsonArr)
+		}
+	}
+	return err
+}
+
+// publishSplit publishes the two halves of the given JSON array separately. The batch is considered to be
+// published only if both the halves are accepted by the server. The halves are split the same way when the batch is
+// retried, hence their keys are derived from the ID of the batch.
+func (publisher *Publisher) publishSplit(id string, jsonArr string) error {
+	first, second, err := splitJSONArray(jsonArr)
+	if err != nil {
+		publisher.Logger.Debugf("Could not split the batch any further : %v", err)
+		return &PermanentError{StatusCode: http.StatusRequestEntityTooLarge}
+	}
+	err = publisher.publishBatch(id+".1", first)
+	if _, ok := err.(*PermanentError); err != nil && !ok {
+		return err
+	}
+	// The second half is sent even if the first one was rejected, since it would be dropped otherwise
+	secondErr := publisher.publishBatch(id+".2", second)
+	if secondErr != nil {
+		return secondErr
+	}
+	return err
+}
+
+// send posts the body to SP. The ID of the batch is sent as the idempotency key, so that SP can discard a batch
+// which it already accepted, but is sent again since the response did not reach the agent.
+func (publisher *Publisher) send(body *bytes.Buffer, contentEncoding string, id string) error {
+	signature := publisher.sign(body.Bytes())
+	req, err := http.NewRequest("POST", publisher.SpServerUrl, body)
 	if err != nil {
 		return fmt.Errorf("could not make a new request : %v", err)
 	}
+	req.Header.Set(idempotencyKeyHeader, id)
+	if signature != "" {
+		signatureHeader := publisher.SignatureHeader
+		if signatureHeader == "" {
+			signatureHeader = defaultSignatureHeader
+		}
+		req.Header.Set(signatureHeader, signature)
+	}
+
+	if publisher.inFlight != nil {
+		publisher.inFlight <- struct{}{}
+		defer func() { <-publisher.inFlight }()
+	}
+	req.Header.Set("Content-Type", publisher.codec().ContentType())
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	return doRequest(publisher.HttpClient, req, publisher.classifier())
+}
 
-	client := publisher.HttpClient
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Content-Encoding", "gzip")
+// sign returns the HMAC-SHA256 signature of the body in the form sha256=<hex>, or an empty string if the
+// signing secret is not set
+func (publisher *Publisher) sign(body []byte) string {
+	if publisher.SigningSecret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(publisher.SigningSecret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// doRequest sends the request and classifies the response received from the server
+func doRequest(client *http.Client, req *http.Request, classifier ResponseClassifier) error {
 	res, err := client.Do(req)
 	if err != nil {
 		return fmt.Errorf("could not receive a response from the server : %v", err)
 	}
-	if res != nil && res.StatusCode != 200 {
-		return fmt.Errorf("received a bad response code from the server, received response code : %d",
-			res.StatusCode)
+	if res.Body != nil {
+		defer func() {
+			_, _ = io.Copy(ioutil.Discard, res.Body)
+			_ = res.Body.Close()
+		}()
 	}
-	return nil
+	if classifier == nil {
+		classifier = &DefaultResponseClassifier{}
+	}
+	return classifier.Classify(res)
+}
+
+// splitJSONArray splits a JSON array into two JSON arrays having half of the elements each
+func splitJSONArray(jsonArr string) (string, string, error) {
+	var elements []json.RawMessage
+	err := json.Unmarshal([]byte(jsonArr), &elements)
+	if err != nil {
+		return "", "", fmt.Errorf("could not unmarshal the JSON array : %v", err)
+	}
+	if len(elements) < 2 {
+		return "", "", fmt.Errorf("JSON array has only %d elements", len(elements))
+	}
+	middle := len(elements) / 2
+	return joinJSONArray(elements[:middle]), joinJSONArray(elements[middle:]), nil
+}
+
+func joinJSONArray(elements []json.RawMessage) string {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, element := range elements {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(element)
+	}
+	buf.WriteByte(']')
+	return buf.String()
+}
+
+func (publisher *Publisher) codec() codec.Codec {
+	if publisher.Codec == nil {
+		jsonCodec, _ := codec.New(codec.JSON, codec.TelemetrySchema)
+		return jsonCodec
+	}
+	return publisher.Codec
+}
+
+func (publisher *Publisher) compressor() Compressor {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	if publisher.Compressor == nil {
+		return &gzipCompressor{level: gzip.DefaultCompression}
+	}
+	return publisher.Compressor
+}
+
+func (publisher *Publisher) fallbackToGzip() {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	publisher.Compressor = &gzipCompressor{level: gzip.DefaultCompression}
+}
+
+func (publisher *Publisher) classifier() ResponseClassifier {
+	if publisher.Classifier == nil {
+		return &DefaultResponseClassifier{}
+	}
+	return publisher.Classifier
+}
+
+func (publisher *Publisher) init() {
+	publisher.initOnce.Do(func() {
+		if publisher.MaxInFlightRequests > 0 {
+			publisher.inFlight = make(chan struct{}, publisher.MaxInFlightRequests)
+		}
+		if publisher.RecordsPerSecond > 0 {
+			publisher.recordsLimiter = newRateLimiter(publisher.RecordsPerSecond)
+		}
+		if publisher.BytesPerSecond > 0 {
+			publisher.bytesLimiter = newRateLimiter(publisher.BytesPerSecond)
+		}
+	})
+}
+
+// throttle waits until the records and the JSON bytes of the batch are allowed by the configured rate limits
+func (publisher *Publisher) throttle(batch io.ReadSeeker) {
+	var waited time.Duration
+	if publisher.recordsLimiter != nil {
+		records, err := countRecords(batch)
+		if err != nil {
+			publisher.Logger.Debugf("Could not count the records of the batch : %v", err)
+		}
+		waited += publisher.recordsLimiter.wait(records)
+	}
+	if publisher.bytesLimiter != nil {
+		size, err := batch.Seek(0, io.SeekEnd)
+		if err != nil {
+			publisher.Logger.Debugf("Could not measure the size of the batch : %v", err)
+		}
+		waited += publisher.bytesLimiter.wait(int(size))
+	}
+	if waited > 0 {
+		publisher.Logger.Debugf("Batch was throttled for %s", waited)
+		publisher.count(func(stats *Statistics) { stats.Throttled += waited })
+	}
+}
+
+// countRecords counts the records of the JSON array without holding the records in memory
+func countRecords(batch io.ReadSeeker) (int, error) {
+	if _, err := batch.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	decoder := json.NewDecoder(batch)
+	if _, err := decoder.Token(); err != nil {
+		return 0, err
+	}
+	records := 0
+	for ; decoder.More(); records++ {
+		var record json.RawMessage
+		if err := decoder.Decode(&record); err != nil {
+			return records, err
+		}
+	}
+	return records, nil
+}
+
+// readerPersister returns the persister if the batches can be streamed to SP straight from the store, which is
+// only the case if SP is the sole destination of the batches
+func (publisher *Publisher) readerPersister() (store.ReaderPersister, bool) {
+	if !publisher.Streaming || publisher.SpServerUrl == "" || len(publisher.Exporters) > 0 {
+		return nil, false
+	}
+	persister, ok := publisher.Persister.(store.ReaderPersister)
+	return persister, ok
+}
+
+func (publisher *Publisher) ordered() bool {
+	persister, ok := publisher.Persister.(store.OrderedPersister)
+	return ok && persister.Ordered()
+}
+
+func (publisher *Publisher) backOff(wait time.Duration) {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	publisher.retryAt = time.Now().Add(wait)
+}
+
+func (publisher *Publisher) retryWait() time.Duration {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	return time.Until(publisher.retryAt)
+}
+
+func (publisher *Publisher) count(update func(stats *Statistics)) {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	update(&publisher.stats)
+}
+
+// Stats returns a snapshot of the number of batches published, retried and dropped so far
+func (publisher *Publisher) Stats() Statistics {
+	publisher.lock.Lock()
+	defer publisher.lock.Unlock()
+	return publisher.stats
+}
+
+// reportStats logs the number of batches handled since the previous report, so that the batches retried while the
+// server is failing can be told apart from the batches which were lost since the server rejected them, along with
+// how long the batches waited for the rate limits
+func (publisher *Publisher) reportStats() {
+	stats := publisher.Stats()
+	since := stats.since(publisher.reportedStats)
+	publisher.reportedStats = stats
+	if since == (Statistics{}) {
+		return
+	}
+	log := publisher.Logger.Infof
+	if since.Dropped > 0 {
+		log = publisher.Logger.Warnf
+	}
+	log("Published %d, retried %d and dropped %d batches since the previous report", since.Published,
+		since.Retried, since.Dropped)
+	if since.Throttled > 0 {
+		publisher.Logger.Infof("Batches waited %s for the rate limits since the previous report", since.Throttled)
+	}
+}
+
+// since returns the statistics accumulated after the previous snapshot
+func (stats Statistics) since(previous Statistics) Statistics {
+	return Statistics{
+		Published: stats.Published - previous.Published,
+		Retried:   stats.Retried - previous.Retried,
+		Dropped:   stats.Dropped - previous.Dropped,
+		Throttled: stats.Throttled - previous.Throttled,
+	}
+}
+
+func (round *drainRound) stop() {
+	round.lock.Lock()
+	defer round.lock.Unlock()
+	round.stopped = true
+}
+
+func (round *drainRound) isStopped() bool {
+	round.lock.Lock()
+	defer round.lock.Unlock()
+	return round.stopped
 }
@@ -21,15 +21,29 @@ package publisher
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store/memory"
 )
 
 var (
@@ -42,11 +56,48 @@ var (
 	metricsCounter int
 )
 
+// testBatchID is the ID of the batches exported in the tests
+const testBatchID = "1"
+
 type (
 	RoundTripFunc      func(req *http.Request) *http.Response
 	MockPersister      struct{}
 	MockPersisterError struct{}
 	MockTransaction    struct{}
+	MockBatchPersister struct {
+		batches    []string
+		committed  int
+		rolledBack int
+		// ids are the IDs of the batches, which are assigned when a batch is fetched for the first time
+		ids map[string]string
+	}
+	MockBatchTransaction struct {
+		persister *MockBatchPersister
+		batch     string
+		id        string
+	}
+	// MockTrackingExporter is an exporter which remembers the batches whose progress was forgotten
+	MockTrackingExporter struct {
+		finished []string
+	}
+	// testReceiver is a stand-in server for SP and the exporters, which keeps the requests it received. Each request
+	// is answered with the status code and the body returned by respond, or with 200 if respond is not set.
+	testReceiver struct {
+		server   *httptest.Server
+		lock     sync.Mutex
+		requests []*receivedRequest
+		respond  func(index int, req *receivedRequest) (int, string)
+	}
+	// receivedRequest is a request kept by the testReceiver along with its whole body and the status code it was
+	// answered with
+	receivedRequest struct {
+		path       string
+		header     http.Header
+		trailer    http.Header
+		body       []byte
+		chunked    bool
+		statusCode int
+	}
 )
 
 func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -59,6 +110,10 @@ func NewTestClient(fn RoundTripFunc) *http.Client {
 	}
 }
 
+func (mockTransaction *MockTransaction) ID() string {
+	return testBatchID
+}
+
 func (mockTransaction *MockTransaction) Commit() error {
 	metricsCounter--
 	return nil
@@ -86,6 +141,44 @@ func (mockPersister *MockPersisterError) Fetch() (string, store.Transaction, err
 	return "", &MockTransaction{}, fmt.Errorf("test error 1")
 }
 
+func (mockTransaction *MockBatchTransaction) ID() string {
+	return mockTransaction.id
+}
+
+func (mockTransaction *MockBatchTransaction) Commit() error {
+	mockTransaction.persister.committed++
+	return nil
+}
+
+func (mockTransaction *MockBatchTransaction) Rollback() error {
+	if mockTransaction.batch != "" {
+		mockTransaction.persister.rolledBack++
+	}
+	return nil
+}
+
+func (mockPersister *MockBatchPersister) Write(str string) error {
+	mockPersister.batches = append(mockPersister.batches, str)
+	return nil
+}
+
+func (mockPersister *MockBatchPersister) Fetch() (string, store.Transaction, error) {
+	if len(mockPersister.batches) == 0 {
+		return "", &MockBatchTransaction{persister: mockPersister}, nil
+	}
+	batch := mockPersister.batches[0]
+	mockPersister.batches = mockPersister.batches[1:]
+	if mockPersister.ids == nil {
+		mockPersister.ids = make(map[string]string)
+	}
+	id, ok := mockPersister.ids[batch]
+	if !ok {
+		id = fmt.Sprintf("batch-%d", len(mockPersister.ids)+1)
+		mockPersister.ids[batch] = id
+	}
+	return batch, &MockBatchTransaction{persister: mockPersister, batch: batch, id: id}, nil
+}
+
 func TestFetchWithMockPersister(t *testing.T) {
 	logger, err := logging.NewLogger()
 	if err != nil {
@@ -120,13 +213,12 @@ func TestFetchWithMockPersister(t *testing.T) {
 			Header:     make(http.Header),
 		}
 	})
-	ticker := time.NewTicker(time.Duration(2) * time.Second)
 	publisher := &Publisher{
-		Ticker:      ticker,
-		Logger:      logger,
-		SpServerUrl: "http://example.com",
-		HttpClient:  client,
-		Persister:   &MockPersister{},
+		SendInterval: 2 * time.Second,
+		Logger:       logger,
+		SpServerUrl:  "http://example.com",
+		HttpClient:   client,
+		Persister:    &MockPersister{},
 	}
 	err = publisher.execute()
 	if err != nil {
@@ -145,6 +237,63 @@ func decodeGzip(w io.Writer, data []byte) error {
 	return err
 }
 
+func newTestReceiver(respond func(index int, req *receivedRequest) (int, string)) *testReceiver {
+	receiver := &testReceiver{respond: respond}
+	receiver.server = httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			// The agent aborts the request if the batch turns out to be invalid while streaming
+			res.WriteHeader(400)
+			return
+		}
+		receiver.lock.Lock()
+		defer receiver.lock.Unlock()
+		received := &receivedRequest{
+			path:       req.URL.Path,
+			header:     req.Header,
+			trailer:    req.Trailer,
+			body:       body,
+			chunked:    len(req.TransferEncoding) > 0 && req.TransferEncoding[0] == "chunked",
+			statusCode: 200,
+		}
+		var resBody string
+		if receiver.respond != nil {
+			received.statusCode, resBody = receiver.respond(len(receiver.requests), received)
+		}
+		receiver.requests = append(receiver.requests, received)
+		res.WriteHeader(received.statusCode)
+		_, _ = io.WriteString(res, resBody)
+	}))
+	return receiver
+}
+
+// respondWith answers all the requests with the given status code
+func respondWith(statusCode int) func(int, *receivedRequest) (int, string) {
+	return func(int, *receivedRequest) (int, string) {
+		return statusCode, ""
+	}
+}
+
+// respondInTurn answers each request with the status code at its index, and with 200 once the codes run out
+func respondInTurn(statusCodes ...int) func(int, *receivedRequest) (int, string) {
+	return func(index int, req *receivedRequest) (int, string) {
+		if index < len(statusCodes) {
+			return statusCodes[index], ""
+		}
+		return 200, ""
+	}
+}
+
+// gunzip returns the decompressed body of the request
+func (req *receivedRequest) gunzip(t *testing.T) []byte {
+	var buf bytes.Buffer
+	err := decodeGzip(&buf, req.body)
+	if err != nil {
+		t.Errorf("Error when decoding gzip : %v", err)
+	}
+	return buf.Bytes()
+}
+
 func TestFetchWithMockPersisterError(t *testing.T) {
 	logger, err := logging.NewLogger()
 	if err != nil {
@@ -158,13 +307,12 @@ func TestFetchWithMockPersisterError(t *testing.T) {
 			Header:     make(http.Header),
 		}
 	})
-	ticker := time.NewTicker(time.Duration(2) * time.Second)
 	publisher := &Publisher{
-		Ticker:      ticker,
-		Logger:      logger,
-		SpServerUrl: "http://example.com",
-		HttpClient:  client,
-		Persister:   &MockPersisterError{},
+		SendInterval: 2 * time.Second,
+		Logger:       logger,
+		SpServerUrl:  "http://example.com",
+		HttpClient:   client,
+		Persister:    &MockPersisterError{},
 	}
 	err = publisher.execute()
 	expectedErr := "failed to fetch the metrics : test error 1"
@@ -183,7 +331,6 @@ func TestFetchWithErrorFromServer(t *testing.T) {
 		t.Errorf("Error building logger: %v", err)
 	}
 	metricsCounter = 1
-	ticker := time.NewTicker(time.Duration(2) * time.Second)
 	client := NewTestClient(func(req *http.Request) *http.Response {
 		return &http.Response{
 			StatusCode: 500,
@@ -191,14 +338,136 @@ func TestFetchWithErrorFromServer(t *testing.T) {
 		}
 	})
 	publisher := &Publisher{
-		Ticker:      ticker,
+		SendInterval: 2 * time.Second,
+		Logger:       logger,
+		SpServerUrl:  "http://example.com",
+		HttpClient:   client,
+		Persister:    &MockPersister{},
+	}
+	err = publisher.execute()
+	expectedErr := "failed to publish the metrics : received a bad response code from the server, received response code : 500"
+	if err == nil {
+		t.Errorf("An error was not thrown, but expected : %s", expectedErr)
+		return
+	}
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+}
+
+func TestFetchWithNoContentFromServer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	metricsCounter = 1
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 204,
+			Header:     make(http.Header),
+		}
+	})
+	publisher := &Publisher{
 		Logger:      logger,
 		SpServerUrl: "http://example.com",
 		HttpClient:  client,
 		Persister:   &MockPersister{},
 	}
 	err = publisher.execute()
-	expectedErr := "failed to publish the metrics : received a bad response code from the server, received response code : 500"
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	if stats := publisher.Stats(); stats.Published != 1 {
+		t.Errorf("Expected 1 published batch, but received : %d", stats.Published)
+	}
+}
+
+func TestFetchWithPermanentErrorFromServer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	metricsCounter = 1
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 400,
+			Header:     make(http.Header),
+		}
+	})
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   &MockPersister{},
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	if metricsCounter != 0 {
+		t.Errorf("The rejected batch was not committed, remaining batches : %d", metricsCounter)
+	}
+	stats := publisher.Stats()
+	if stats.Dropped != 1 || stats.Retried != 0 {
+		t.Errorf("Expected 1 dropped and 0 retried batches, but received : %d dropped, %d retried",
+			stats.Dropped, stats.Retried)
+	}
+}
+
+func TestReportStats(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	publisher := &Publisher{
+		Logger: zap.New(core).Sugar(),
+	}
+	publisher.count(func(stats *Statistics) { stats.Published += 3 })
+	publisher.count(func(stats *Statistics) { stats.Retried += 2 })
+	publisher.reportStats()
+	publisher.count(func(stats *Statistics) { stats.Dropped++ })
+	publisher.reportStats()
+	// Nothing is reported when no batch was handled since the previous report
+	publisher.reportStats()
+	expectedLogs := []struct {
+		level   zapcore.Level
+		message string
+	}{
+		{level: zap.InfoLevel, message: "Published 3, retried 2 and dropped 0 batches since the previous report"},
+		{level: zap.WarnLevel, message: "Published 0, retried 0 and dropped 1 batches since the previous report"},
+	}
+	entries := logs.All()
+	if len(entries) != len(expectedLogs) {
+		t.Fatalf("Expected %d log entries, but received %d", len(expectedLogs), len(entries))
+	}
+	for i, expectedLog := range expectedLogs {
+		if entries[i].Level != expectedLog.level || entries[i].Message != expectedLog.message {
+			t.Errorf("Expected the log %s at %s, but received %s at %s", expectedLog.message, expectedLog.level,
+				entries[i].Message, entries[i].Level)
+		}
+	}
+}
+
+func TestFetchWithRetryAfterFromServer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	metricsCounter = 1
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		header := make(http.Header)
+		header.Set("Retry-After", "120")
+		return &http.Response{
+			StatusCode: 503,
+			Header:     header,
+		}
+	})
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   &MockPersister{},
+	}
+	err = publisher.execute()
+	expectedErr := "failed to publish the metrics : received a bad response code from the server, received " +
+		"response code : 503, retry after : 2m0s"
 	if err == nil {
 		t.Errorf("An error was not thrown, but expected : %s", expectedErr)
 		return
@@ -206,4 +475,730 @@ func TestFetchWithErrorFromServer(t *testing.T) {
 	if err.Error() != expectedErr {
 		t.Errorf("Expected error was not thrown, received error : %v", err)
 	}
+	if time.Until(publisher.retryAt) < time.Minute {
+		t.Errorf("Retry-After header was not honoured, retrying at : %s", publisher.retryAt)
+	}
+	if stats := publisher.Stats(); stats.Retried != 1 || stats.Dropped != 0 {
+		t.Errorf("Expected 1 retried and 0 dropped batches, but received : %d retried, %d dropped",
+			stats.Retried, stats.Dropped)
+	}
+}
+
+func TestDefaultResponseClassifier(t *testing.T) {
+	now := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		statusCode int
+		retryAfter string
+		expected   error
+	}{
+		{200, "", nil},
+		{202, "", nil},
+		{204, "", nil},
+		{400, "", &PermanentError{StatusCode: 400}},
+		{413, "", &PermanentError{StatusCode: 413}},
+		{408, "", &RetryableError{StatusCode: 408}},
+		{429, "30", &RetryableError{StatusCode: 429, RetryAfter: 30 * time.Second}},
+		{503, "", &RetryableError{StatusCode: 503}},
+		{500, "10", &RetryableError{StatusCode: 500}},
+	}
+	classifier := &DefaultResponseClassifier{}
+	for _, test := range tests {
+		header := make(http.Header)
+		if test.retryAfter != "" {
+			header.Set("Retry-After", test.retryAfter)
+		}
+		err := classifier.Classify(&http.Response{StatusCode: test.statusCode, Header: header})
+		if fmt.Sprint(err) != fmt.Sprint(test.expected) {
+			t.Errorf("Unexpected classification for %d, expected : %v, received : %v", test.statusCode,
+				test.expected, err)
+		}
+	}
+	if wait := parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now); wait != 90*time.Second {
+		t.Errorf("Retry-After date was not parsed correctly, received : %s", wait)
+	}
+}
+
+func TestFetchWithPayloadTooLargeFromServer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var received []string
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		var buf bytes.Buffer
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		err := decodeGzip(&buf, bytesArr)
+		if err != nil {
+			t.Errorf("Error when decoding gzip : %v", err)
+		}
+		// Only accept batches with a single element
+		if strings.Contains(buf.String(), "},{") {
+			return &http.Response{
+				StatusCode: 413,
+				Header:     make(http.Header),
+			}
+		}
+		received = append(received, buf.String())
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"id\":1},{\"id\":2},{\"id\":3}]"},
+	}
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   persister,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	expected := []string{"[{\"id\":1}]", "[{\"id\":2}]", "[{\"id\":3}]"}
+	if fmt.Sprint(received) != fmt.Sprint(expected) {
+		t.Errorf("Batch was not split as expected, expected : %v, received : %v", expected, received)
+	}
+	if persister.committed != 1 || persister.rolledBack != 0 {
+		t.Errorf("Expected the batch to be committed once, committed : %d, rolled back : %d",
+			persister.committed, persister.rolledBack)
+	}
+}
+
+func TestFetchWithMaxPayloadBytes(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var records []string
+	for i := 0; i < 4; i++ {
+		records = append(records, fmt.Sprintf("{\"id\":\"%x\"}", sha256.Sum256([]byte{byte(i)})))
+	}
+	batch := fmt.Sprintf("[%s]", strings.Join(records, ","))
+	var buf bytes.Buffer
+	g := gzip.NewWriter(&buf)
+	_, _ = g.Write([]byte(fmt.Sprintf("[%s]", records[0])))
+	_ = g.Close()
+	maxPayloadBytes := buf.Len() + 10
+
+	requests := 0
+	var keys []string
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		requests++
+		keys = append(keys, req.Header.Get("Idempotency-Key"))
+		if req.ContentLength > int64(maxPayloadBytes) {
+			t.Errorf("Payload exceeding the limit was sent, size : %d", req.ContentLength)
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	persister := &MockBatchPersister{
+		batches: []string{batch},
+	}
+	publisher := &Publisher{
+		Logger:          logger,
+		SpServerUrl:     "http://example.com",
+		HttpClient:      client,
+		Persister:       persister,
+		MaxPayloadBytes: maxPayloadBytes,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("Expected the batch to be split into 4 requests, but sent %d requests", requests)
+	}
+	// The keys of the halves are derived from the ID of the batch, hence they are the same when the batch is retried
+	if fmt.Sprint(keys) != "[batch-1.1.1 batch-1.1.2 batch-1.2.1 batch-1.2.2]" {
+		t.Errorf("Unexpected idempotency keys : %v", keys)
+	}
+	if persister.committed != 1 {
+		t.Errorf("Expected the batch to be committed once, committed : %d", persister.committed)
+	}
+
+	// A single record larger than the limit cannot be split any further
+	persister.batches = []string{batch}
+	publisher.MaxPayloadBytes = 20
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	if requests != 4 {
+		t.Errorf("Records larger than the limit should not be sent, but sent %d requests", requests-4)
+	}
+	if stats := publisher.Stats(); stats.Dropped != 1 || persister.committed != 2 {
+		t.Errorf("Expected the oversized batch to be dropped, dropped : %d, committed : %d", stats.Dropped,
+			persister.committed)
+	}
+}
+
+func (exporter *MockTrackingExporter) Name() string {
+	return "tracking"
+}
+
+func (exporter *MockTrackingExporter) Export(id string, jsonArr string) error {
+	return nil
+}
+
+func (exporter *MockTrackingExporter) finish(id string) {
+	exporter.finished = append(exporter.finished, id)
+}
+
+func TestFetchForgetsProgress(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	statusCode := 503
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     make(http.Header),
+		}
+	})
+	exporter := &MockTrackingExporter{}
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"requestPath\":\"/pets\"}]"},
+	}
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   persister,
+		Exporters:   []Exporter{exporter},
+	}
+	err = publisher.execute()
+	if err == nil {
+		t.Error("An error was not thrown, but expected one from the server")
+	}
+	// The progress is kept while the batch is in the store, and forgotten once the batch leaves it
+	if len(exporter.finished) != 0 || len(publisher.exported) != 1 {
+		t.Errorf("Expected the progress of the batch to be kept, but finished : %v", exporter.finished)
+	}
+	statusCode = 400
+	persister.batches = []string{"[{\"requestPath\":\"/pets\"}]"}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if fmt.Sprint(exporter.finished) != "[batch-1]" || len(publisher.exported) != 0 {
+		t.Errorf("Expected the progress of the batch to be forgotten, but finished : %v, exported : %v",
+			exporter.finished, publisher.exported)
+	}
+}
+
+func TestFetchWithConcurrentWorkers(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var lock sync.Mutex
+	inFlight, maxInFlight, received := 0, 0, 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		lock.Lock()
+		inFlight--
+		received++
+		lock.Unlock()
+		res.WriteHeader(200)
+	}))
+	defer testServer.Close()
+	persister, err := memory.NewPersister(10, 2, logger)
+	if err != nil {
+		t.Errorf("Could not create the persister : %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		_ = persister.Write(fmt.Sprintf("[{\"id\":%d}]", i))
+	}
+	publisher := &Publisher{
+		Logger:              logger,
+		SpServerUrl:         testServer.URL,
+		HttpClient:          &http.Client{},
+		Persister:           persister,
+		Workers:             4,
+		MaxInFlightRequests: 2,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	if received != 20 {
+		t.Errorf("Expected 20 batches to be received, but received : %d", received)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, but there were : %d", maxInFlight)
+	}
+	if stats := publisher.Stats(); stats.Published != 20 {
+		t.Errorf("Expected 20 published batches, but received : %d", stats.Published)
+	}
+}
+
+func TestFetchWithConcurrentWorkersPreservingOrder(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var lock sync.Mutex
+	var received []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		var buf bytes.Buffer
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		_ = decodeGzip(&buf, bytesArr)
+		lock.Lock()
+		received = append(received, buf.String())
+		lock.Unlock()
+		res.WriteHeader(200)
+	}))
+	defer testServer.Close()
+	persister, err := memory.NewPersister(10, 2, logger)
+	if err != nil {
+		t.Errorf("Could not create the persister : %v", err)
+	}
+	var expected []string
+	for i := 0; i < 10; i++ {
+		batch := fmt.Sprintf("[{\"id\":%d}]", i)
+		expected = append(expected, batch)
+		_ = persister.Write(batch)
+	}
+	publisher := &Publisher{
+		Logger:        logger,
+		SpServerUrl:   testServer.URL,
+		HttpClient:    &http.Client{},
+		Persister:     persister,
+		Workers:       4,
+		PreserveOrder: true,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	if fmt.Sprint(received) != fmt.Sprint(expected) {
+		t.Errorf("Batches were not published in order, expected : %v, received : %v", expected, received)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := &rateLimiter{rate: 10, tokens: 10, last: now}
+	if delay := limiter.reserve(10, now); delay != 0 {
+		t.Errorf("Expected the burst to be allowed without waiting, but waited : %s", delay)
+	}
+	if delay := limiter.reserve(5, now); delay != 500*time.Millisecond {
+		t.Errorf("Expected to wait 500ms, but waited : %s", delay)
+	}
+	// A request larger than the bucket leaves the bucket in debt
+	if delay := limiter.reserve(20, now.Add(time.Second)); delay != 1500*time.Millisecond {
+		t.Errorf("Expected to wait 1.5s, but waited : %s", delay)
+	}
+	if delay := limiter.reserve(10, now.Add(10*time.Second)); delay != 0 {
+		t.Errorf("Expected the bucket to be refilled, but waited : %s", delay)
+	}
+}
+
+func TestFetchWithRateLimits(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	var records []string
+	for i := 0; i < 10; i++ {
+		records = append(records, fmt.Sprintf("{\"id\":%d}", i))
+	}
+	batch := fmt.Sprintf("[%s]", strings.Join(records, ","))
+	persister := &MockBatchPersister{
+		batches: []string{batch, batch, "[{\"id\":1},{\"id\":2}]"},
+	}
+	publisher := &Publisher{
+		Logger:           logger,
+		SpServerUrl:      "http://example.com",
+		HttpClient:       client,
+		Persister:        persister,
+		RecordsPerSecond: 20,
+	}
+	start := time.Now()
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	// The last batch needs to wait for 2 records worth of tokens
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("Expected the batches to be throttled, but publishing took : %s", elapsed)
+	}
+	if stats := publisher.Stats(); stats.Throttled == 0 || stats.Published != 3 {
+		t.Errorf("Expected 3 throttled batches to be published, throttled for : %s, published : %d",
+			stats.Throttled, stats.Published)
+	}
+}
+
+func TestFetchWithRateLimitsAndPayloadTooLarge(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	requests := 0
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		requests++
+		var buf bytes.Buffer
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		_ = decodeGzip(&buf, bytesArr)
+		statusCode := 200
+		if strings.Contains(buf.String(), "},{") {
+			statusCode = 413
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     make(http.Header),
+		}
+	})
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"id\":1},{\"id\":2},{\"id\":3}]"},
+	}
+	publisher := &Publisher{
+		Logger:           logger,
+		SpServerUrl:      "http://example.com",
+		HttpClient:       client,
+		Persister:        persister,
+		RecordsPerSecond: 3,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	// The records sent again after splitting the batch do not wait for the rate limits again
+	if stats := publisher.Stats(); stats.Throttled != 0 || stats.Published != 1 || requests != 5 {
+		t.Errorf("Expected the batch to be published without throttling, throttled for : %s, published : %d, "+
+			"requests : %d", stats.Throttled, stats.Published, requests)
+	}
+}
+
+func TestRunWithNotification(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	received := make(chan struct{}, 10)
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(200)
+		received <- struct{}{}
+	}))
+	defer testServer.Close()
+	persister, err := memory.NewPersister(10, 2, logger)
+	if err != nil {
+		t.Errorf("Could not create the persister : %v", err)
+	}
+	notifyCh := make(chan struct{}, 1)
+	stopCh := make(chan struct{})
+	publisher := &Publisher{
+		SendInterval: time.Hour,
+		Notify:       notifyCh,
+		Logger:       logger,
+		SpServerUrl:  testServer.URL,
+		HttpClient:   &http.Client{},
+		Persister:    persister,
+	}
+	done := make(chan struct{})
+	go func() {
+		publisher.Run(stopCh)
+		close(done)
+	}()
+	_ = persister.Write(fmt.Sprintf("[%s]", testStr))
+	notifyCh <- struct{}{}
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Error("The batch was not published after the notification")
+	}
+	close(stopCh)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("The publisher did not stop")
+	}
+}
+
+func TestRunWithNotificationAfterFailure(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	received := make(chan struct{}, 10)
+	testServer := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.WriteHeader(500)
+		received <- struct{}{}
+	}))
+	defer testServer.Close()
+	persister, err := memory.NewPersister(10, 2, logger)
+	if err != nil {
+		t.Errorf("Could not create the persister : %v", err)
+	}
+	notifyCh := make(chan struct{}, 1)
+	stopCh := make(chan struct{})
+	publisher := &Publisher{
+		SendInterval: time.Hour,
+		Notify:       notifyCh,
+		Logger:       logger,
+		SpServerUrl:  testServer.URL,
+		HttpClient:   &http.Client{},
+		Persister:    persister,
+	}
+	done := make(chan struct{})
+	go func() {
+		publisher.Run(stopCh)
+		close(done)
+	}()
+	_ = persister.Write(fmt.Sprintf("[%s]", testStr))
+	notifyCh <- struct{}{}
+	select {
+	case <-received:
+	case <-time.After(5 * time.Second):
+		t.Error("The batch was not published after the notification")
+	}
+	// The batches persisted after the failure wait for the send interval instead of being retried right away
+	_ = persister.Write(fmt.Sprintf("[%s]", testStr))
+	notifyCh <- struct{}{}
+	select {
+	case <-received:
+		t.Error("Expected the publisher to wait for the send interval after a failure")
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(stopCh)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("The publisher did not stop")
+	}
+}
+
+func TestFetchWithNDJSONCodec(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("Unexpected content type : %s", req.Header.Get("Content-Type"))
+		}
+		var buf bytes.Buffer
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		_ = decodeGzip(&buf, bytesArr)
+		if buf.String() != "{\"id\":1}\n{\"id\":2}\n" {
+			t.Errorf("Unexpected body : %s", buf.String())
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	ndjsonCodec, err := codec.New(codec.NDJSON, codec.TelemetrySchema)
+	if err != nil {
+		t.Errorf("Could not create the codec : %v", err)
+	}
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"id\":1},{\"id\":2}]", "[{\"id\":"},
+	}
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   persister,
+		Codec:       ndjsonCodec,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	// The malformed batch cannot be encoded, hence it is dropped
+	if stats := publisher.Stats(); stats.Published != 1 || stats.Dropped != 1 {
+		t.Errorf("Expected 1 published and 1 dropped batch, but received : %d published, %d dropped",
+			stats.Published, stats.Dropped)
+	}
+}
+
+func TestCompressors(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      int
+		encoding   string
+		decompress func(r io.Reader) (io.Reader, error)
+	}{
+		{NoCompression, 0, "", func(r io.Reader) (io.Reader, error) { return r, nil }},
+		{"", 0, "gzip", func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{GzipCompression, 9, "gzip", func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{ZstdCompression, 3, "zstd", func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) }},
+		{SnappyCompression, 0, "snappy", func(r io.Reader) (io.Reader, error) { return snappy.NewReader(r), nil }},
+	}
+	for _, test := range tests {
+		compressor, err := NewCompressor(test.name, test.level)
+		if err != nil {
+			t.Errorf("Could not create the %s compressor : %v", test.name, err)
+			continue
+		}
+		if compressor.ContentEncoding() != test.encoding {
+			t.Errorf("Unexpected content encoding, expected : %s, received : %s", test.encoding,
+				compressor.ContentEncoding())
+		}
+		var buf bytes.Buffer
+		w, err := compressor.NewWriter(&buf)
+		if err != nil {
+			t.Errorf("Could not create the %s writer : %v", test.name, err)
+			continue
+		}
+		_, _ = io.WriteString(w, testStr)
+		_ = w.Close()
+		r, err := test.decompress(&buf)
+		if err != nil {
+			t.Errorf("Could not create the %s reader : %v", test.name, err)
+			continue
+		}
+		data, err := ioutil.ReadAll(r)
+		if err != nil || string(data) != testStr {
+			t.Errorf("Could not decompress the %s payload : %v, received : %s", test.name, err, string(data))
+		}
+	}
+	_, err := NewCompressor("lz4", 0)
+	if err == nil || err.Error() != "unknown compression lz4" {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+	_, err = NewCompressor(GzipCompression, 12)
+	if err == nil || err.Error() != "invalid gzip compression level 12" {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+}
+
+func TestFetchWithUnsupportedCompression(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var encodings []string
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		encodings = append(encodings, req.Header.Get("Content-Encoding"))
+		if req.Header.Get("Content-Encoding") != "gzip" {
+			return &http.Response{
+				StatusCode: 415,
+				Header:     make(http.Header),
+			}
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	compressor, _ := NewCompressor(ZstdCompression, 0)
+	persister := &MockBatchPersister{
+		batches: []string{fmt.Sprintf("[%s]", testStr), fmt.Sprintf("[%s]", testStr)},
+	}
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   persister,
+		Compressor:  compressor,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	expected := "[zstd gzip gzip]"
+	if fmt.Sprint(encodings) != expected {
+		t.Errorf("Publisher did not fall back to gzip, expected : %s, received : %v", expected, encodings)
+	}
+	if stats := publisher.Stats(); stats.Published != 2 {
+		t.Errorf("Expected 2 published batches, but received : %d", stats.Published)
+	}
+}
+
+func TestFetchWithIdempotencyKeyAndSignature(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	batch := fmt.Sprintf("[%s]", testStr)
+	var keys []string
+	statusCode := 503
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		keys = append(keys, req.Header.Get("Idempotency-Key"))
+		body, _ := ioutil.ReadAll(req.Body)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write(body)
+		if expected := "sha256=" + hex.EncodeToString(mac.Sum(nil)); req.Header.Get("X-Signature") != expected {
+			t.Errorf("Expected the signature %s, but received : %s", expected, req.Header.Get("X-Signature"))
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     make(http.Header),
+		}
+	})
+	persister := &MockBatchPersister{
+		batches: []string{batch},
+	}
+	publisher := &Publisher{
+		Logger:          logger,
+		SpServerUrl:     "http://example.com",
+		HttpClient:      client,
+		Persister:       persister,
+		SigningSecret:   "secret",
+		SignatureHeader: "X-Signature",
+	}
+	err = publisher.execute()
+	if err == nil {
+		t.Error("An error was not thrown, but expected one from the server")
+	}
+	// The batch is sent again with the same key once it is fetched again after the rollback
+	statusCode = 200
+	persister.batches = []string{batch}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "batch-1" || keys[1] != keys[0] {
+		t.Errorf("Expected the idempotency key batch-1 in both the requests, but received : %v", keys)
+	}
+}
+
+func TestFetchWithoutSigningSecret(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	metricsCounter = 1
+	client := NewTestClient(func(req *http.Request) *http.Response {
+		if req.Header.Get("X-Cellery-Signature") != "" {
+			t.Errorf("Expected no signature, but received : %s", req.Header.Get("X-Cellery-Signature"))
+		}
+		if req.Header.Get("Idempotency-Key") == "" {
+			t.Error("Expected an idempotency key, but received none")
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(http.Header),
+		}
+	})
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: "http://example.com",
+		HttpClient:  client,
+		Persister:   &MockPersister{},
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
 }
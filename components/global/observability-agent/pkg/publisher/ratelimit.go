@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// rateLimiter is a token bucket which is refilled at the given rate per second and holds at most one second
+	// worth of tokens. A request larger than the bucket is still allowed, leaving the bucket in debt, so that
+	// the following requests wait until the debt is paid off.
+	rateLimiter struct {
+		lock   sync.Mutex
+		rate   float64
+		tokens float64
+		last   time.Time
+	}
+)
+
+func newRateLimiter(ratePerSecond int) *rateLimiter {
+	return &rateLimiter{
+		rate:   float64(ratePerSecond),
+		tokens: float64(ratePerSecond),
+		last:   time.Now(),
+	}
+}
+
+// wait blocks until n tokens are available and returns the time spent waiting
+func (limiter *rateLimiter) wait(n int) time.Duration {
+	delay := limiter.reserve(n, time.Now())
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return delay
+}
+
+// reserve takes n tokens from the bucket and returns how long the caller has to wait before using them
+func (limiter *rateLimiter) reserve(n int, now time.Time) time.Duration {
+	limiter.lock.Lock()
+	defer limiter.lock.Unlock()
+	if elapsed := now.Sub(limiter.last); elapsed > 0 {
+		limiter.tokens += elapsed.Seconds() * limiter.rate
+		if limiter.tokens > limiter.rate {
+			limiter.tokens = limiter.rate
+		}
+		limiter.last = now
+	}
+	limiter.tokens -= float64(n)
+	if limiter.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-limiter.tokens / limiter.rate * float64(time.Second))
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// ResponseClassifier decides whether a response from the server means that the batch was accepted, should be
+	// retried later or was rejected for good. A nil error means that the batch was accepted.
+	ResponseClassifier interface {
+		Classify(res *http.Response) error
+	}
+
+	// DefaultResponseClassifier accepts any 2xx response, retries 408, 429 and 5xx responses (honouring
+	// Retry-After) and treats the rest of the 4xx responses as permanent failures.
+	DefaultResponseClassifier struct{}
+
+	// RetryableError is returned when the server could not accept the batch now, but may accept it later
+	RetryableError struct {
+		StatusCode int
+		RetryAfter time.Duration
+	}
+
+	// PermanentError is returned when the server rejected the batch, or the batch could not be sent at all, and
+	// sending it again would not help
+	PermanentError struct {
+		StatusCode int
+		Reason     string
+	}
+)
+
+func (err *RetryableError) Error() string {
+	if err.RetryAfter > 0 {
+		return fmt.Sprintf("received a bad response code from the server, received response code : %d, "+
+			"retry after : %s", err.StatusCode, err.RetryAfter)
+	}
+	return fmt.Sprintf("received a bad response code from the server, received response code : %d",
+		err.StatusCode)
+}
+
+func (err *PermanentError) Error() string {
+	if err.Reason != "" {
+		return err.Reason
+	}
+	return fmt.Sprintf("the server rejected the batch, received response code : %d", err.StatusCode)
+}
+
+func (classifier *DefaultResponseClassifier) Classify(res *http.Response) error {
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return nil
+	case res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable:
+		return &RetryableError{
+			StatusCode: res.StatusCode,
+			RetryAfter: parseRetryAfter(res.Header.Get("Retry-After"), time.Now()),
+		}
+	case res.StatusCode == http.StatusRequestTimeout:
+		return &RetryableError{StatusCode: res.StatusCode}
+	case res.StatusCode >= 400 && res.StatusCode < 500:
+		return &PermanentError{StatusCode: res.StatusCode}
+	default:
+		return &RetryableError{StatusCode: res.StatusCode}
+	}
+}
+
+// parseRetryAfter reads the Retry-After header which can either be a number of seconds or an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
@@ -0,0 +1,198 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/codec"
+)
+
+// publishStream publishes the batch by streaming its records through the codec and the compressor straight into
+// the request body, using chunked transfer encoding. A request is ended once MaxRequestBytes of records are
+// written to it and the rest of the records are streamed in the following requests, hence the encoded requests are
+// not held in memory. The batch is considered to be published only if all the requests succeed.
+func (publisher *Publisher) publishStream(id string, jsonArr string) error {
+	return publisher.streamFrom(id, strings.NewReader(jsonArr))
+}
+
+// streamFrom publishes the batch read from the reader in the same way as publishStream, so that a batch fetched as
+// a reader from the store is not held in memory either
+func (publisher *Publisher) streamFrom(id string, batch io.ReadSeeker) error {
+	publisher.throttle(batch)
+	return publisher.streamBatch(id, batch)
+}
+
+// streamBatch streams the batch from its first record
+func (publisher *Publisher) streamBatch(id string, batch io.ReadSeeker) error {
+	if _, err := batch.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("could not read the batch : %v", err)
+	}
+	streamingCodec, ok := publisher.codec().(codec.StreamingCodec)
+	if !ok {
+		jsonArr, err := ioutil.ReadAll(batch)
+		if err != nil {
+			return fmt.Errorf("could not read the batch : %v", err)
+		}
+		return publisher.publishBatch(id, string(jsonArr))
+	}
+	read := 0
+	decoder := json.NewDecoder(batch)
+	if _, err := decoder.Token(); err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not decode the batch : %v", err)}
+	}
+	for part := 1; decoder.More(); part++ {
+		partStart := read
+		partID := id + "-" + strconv.Itoa(part)
+		compressor := publisher.compressor()
+		// Each part gets its own key, so that SP can discard the parts it already accepted when the batch is retried
+		err := publisher.sendStream(decoder, streamingCodec, compressor, partID, &read)
+		if permanentErr, ok := err.(*PermanentError); ok {
+			switch {
+			case permanentErr.StatusCode == http.StatusRequestEntityTooLarge:
+				publisher.Logger.Warnf("Server rejected a streamed request as too large, consider lowering the "+
+					"maximum request bytes of %d", publisher.MaxRequestBytes)
+				// The previous parts were already accepted, hence only the rest of the records are split and sent
+				return publisher.publishRemaining(partID, batch, partStart)
+			case permanentErr.StatusCode == http.StatusUnsupportedMediaType &&
+				compressor.ContentEncoding() != GzipCompression:
+				publisher.Logger.Warnf("Server does not support %s compression, falling back to gzip",
+					compressor.ContentEncoding())
+				publisher.fallbackToGzip()
+				return publisher.streamBatch(id, batch)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishRemaining publishes the records of the batch starting from the given index without streaming, using the
+// key of the rejected part
+func (publisher *Publisher) publishRemaining(id string, batch io.ReadSeeker, from int) error {
+	if _, err := batch.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("could not read the batch : %v", err)
+	}
+	var records []json.RawMessage
+	err := json.NewDecoder(batch).Decode(&records)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not decode the batch : %v", err)}
+	}
+	return publisher.publishBatch(id, joinJSONArray(records[from:]))
+}
+
+// sendStream sends a single request with the records read from the decoder, until the decoder runs out of
+// records or the byte budget of the request is used up
+func (publisher *Publisher) sendStream(decoder *json.Decoder, streamingCodec codec.StreamingCodec,
+	compressor Compressor, id string, read *int) error {
+	reader, writer := io.Pipe()
+	req, err := http.NewRequest("POST", publisher.SpServerUrl, reader)
+	if err != nil {
+		return fmt.Errorf("could not make a new request : %v", err)
+	}
+	req.ContentLength = -1
+	req.Header.Set(idempotencyKeyHeader, id)
+	req.Header.Set("Content-Type", streamingCodec.ContentType())
+	if contentEncoding := compressor.ContentEncoding(); contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	// The signature is only known once the whole body is written, hence it is sent as a trailer
+	var mac hash.Hash
+	signatureHeader := publisher.SignatureHeader
+	if signatureHeader == "" {
+		signatureHeader = defaultSignatureHeader
+	}
+	if publisher.SigningSecret != "" {
+		mac = hmac.New(sha256.New, []byte(publisher.SigningSecret))
+		req.Trailer = http.Header{signatureHeader: nil}
+	}
+
+	if publisher.inFlight != nil {
+		publisher.inFlight <- struct{}{}
+		defer func() { <-publisher.inFlight }()
+	}
+	writeErrCh := make(chan error, 1)
+	go func() {
+		var body io.Writer = writer
+		if mac != nil {
+			body = io.MultiWriter(writer, mac)
+		}
+		err := publisher.writeStream(body, decoder, streamingCodec, compressor, read)
+		if err == nil && mac != nil {
+			req.Trailer.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+		writeErrCh <- err
+		_ = writer.CloseWithError(err)
+	}()
+	err = doRequest(publisher.HttpClient, req, publisher.classifier())
+	// Unblocks the writer if the request ended before the whole body was read
+	_ = reader.Close()
+	writeErr := <-writeErrCh
+	// A batch which could not be decoded fails the request as well, but should not be retried
+	if _, ok := writeErr.(*PermanentError); ok {
+		return writeErr
+	}
+	if err != nil {
+		return err
+	}
+	return writeErr
+}
+
+// writeStream encodes the records from the decoder into the body until the byte budget is used up
+func (publisher *Publisher) writeStream(body io.Writer, decoder *json.Decoder, streamingCodec codec.StreamingCodec,
+	compressor Compressor, read *int) error {
+	w, err := compressor.NewWriter(body)
+	if err != nil {
+		return fmt.Errorf("could not create the compressor : %v", err)
+	}
+	encoder := streamingCodec.NewStreamEncoder()
+	if err = encoder.Begin(w); err != nil {
+		return err
+	}
+	written := 0
+	for decoder.More() && (publisher.MaxRequestBytes <= 0 || written < publisher.MaxRequestBytes) {
+		var record json.RawMessage
+		err = decoder.Decode(&record)
+		if err != nil {
+			return &PermanentError{Reason: fmt.Sprintf("could not decode the batch : %v", err)}
+		}
+		*read++
+		err = encoder.EncodeRecord(w, record)
+		if err != nil {
+			return err
+		}
+		written += len(record)
+	}
+	if err = encoder.End(w); err != nil {
+		return err
+	}
+	return w.Close()
+}
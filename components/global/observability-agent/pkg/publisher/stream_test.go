@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store/file"
+)
+
+// streamedRecords returns the records of each request received from the publisher, after checking the signature
+// trailers of the requests if a signing secret is given
+func streamedRecords(t *testing.T, receiver *testReceiver, secret string) [][]json.RawMessage {
+	var records [][]json.RawMessage
+	for _, req := range receiver.requests {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write(req.body)
+		signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		if secret != "" && req.trailer.Get("X-Cellery-Signature") != signature {
+			t.Errorf("Expected the signature trailer %s, but received : %s", signature,
+				req.trailer.Get("X-Cellery-Signature"))
+		}
+		var requestRecords []json.RawMessage
+		err := json.Unmarshal(req.gunzip(t), &requestRecords)
+		if err != nil {
+			t.Errorf("Error when unmarshalling the body : %v", err)
+		}
+		records = append(records, requestRecords)
+	}
+	return records
+}
+
+// idempotencyKeys returns the idempotency keys of the requests received from the publisher
+func idempotencyKeys(receiver *testReceiver) []string {
+	var keys []string
+	for _, req := range receiver.requests {
+		keys = append(keys, req.header.Get("Idempotency-Key"))
+	}
+	return keys
+}
+
+func expectChunked(t *testing.T, requests []*receivedRequest) {
+	for _, req := range requests {
+		if !req.chunked {
+			t.Errorf("Expected a chunked request, but received : %s", req.header.Get("Idempotency-Key"))
+		}
+	}
+}
+
+func TestFetchWithStreaming(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(nil)
+	defer receiver.server.Close()
+	batch := "[{\"requestPath\":\"/pets\"},{\"requestPath\":\"/owners\"},{\"requestPath\":\"/\"}]"
+	persister := &MockBatchPersister{
+		batches: []string{batch},
+	}
+	publisher := &Publisher{
+		Logger:          logger,
+		SpServerUrl:     receiver.server.URL,
+		HttpClient:      &http.Client{},
+		Persister:       persister,
+		SigningSecret:   "secret",
+		Streaming:       true,
+		MaxRequestBytes: 40,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if persister.committed != 1 {
+		t.Errorf("Expected the batch to be committed, but committed : %d", persister.committed)
+	}
+	expectChunked(t, receiver.requests)
+	records := streamedRecords(t, receiver, "secret")
+	// The first two records use up the byte budget of the first request
+	if len(records) != 2 || len(records[0]) != 2 || len(records[1]) != 1 {
+		t.Errorf("Unexpected records in the requests : %v", records)
+		return
+	}
+	if string(records[1][0]) != "{\"requestPath\":\"/\"}" {
+		t.Errorf("Unexpected record in the second request : %s", string(records[1][0]))
+	}
+	if keys := idempotencyKeys(receiver); keys[0] != "batch-1-1" || keys[1] != "batch-1-2" {
+		t.Errorf("Unexpected idempotency keys : %v", keys)
+	}
+}
+
+func TestFetchWithStreamingFromFile(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(nil)
+	defer receiver.server.Close()
+	dir, err := ioutil.TempDir("", "stream")
+	if err != nil {
+		t.Errorf("Could not create the temporary directory : %v", err)
+		return
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	batch := "[{\"requestPath\":\"/pets\"},{\"requestPath\":\"/owners\"},{\"requestPath\":\"/\"}]"
+	_ = ioutil.WriteFile(filepath.Join(dir, "batch.json"), []byte(batch), 0644)
+	persister, err := file.NewPersister(&file.File{Path: dir}, logger)
+	if err != nil {
+		t.Errorf("Could not create the persister : %v", err)
+	}
+	publisher := &Publisher{
+		Logger:          logger,
+		SpServerUrl:     receiver.server.URL,
+		HttpClient:      &http.Client{},
+		Persister:       persister,
+		Streaming:       true,
+		MaxRequestBytes: 40,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 0 {
+		t.Errorf("Expected the batch to be removed from the store, but found : %v", files)
+	}
+	expectChunked(t, receiver.requests)
+	if records := streamedRecords(t, receiver, ""); len(records) != 2 || len(records[0]) != 2 ||
+		len(records[1]) != 1 {
+		t.Errorf("Unexpected records in the requests : %v", records)
+		return
+	}
+	if keys := idempotencyKeys(receiver); keys[0] != "batch-1" || keys[1] != "batch-2" {
+		t.Errorf("Unexpected idempotency keys : %v", keys)
+	}
+}
+
+func TestFetchWithStreamingFailure(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondInTurn(200, 503))
+	defer receiver.server.Close()
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"requestPath\":\"/pets\"},{\"requestPath\":\"/owners\"}]"},
+	}
+	publisher := &Publisher{
+		Logger:          logger,
+		SpServerUrl:     receiver.server.URL,
+		HttpClient:      &http.Client{},
+		Persister:       persister,
+		Streaming:       true,
+		MaxRequestBytes: 1,
+	}
+	err = publisher.execute()
+	expectedErr := "failed to publish the metrics : received a bad response code from the server, received " +
+		"response code : 503"
+	if err == nil || err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+	if persister.rolledBack != 1 || persister.committed != 0 {
+		t.Errorf("Expected the batch to be rolled back, but rolled back : %d, committed : %d",
+			persister.rolledBack, persister.committed)
+	}
+	expectChunked(t, receiver.requests)
+}
+
+func TestFetchWithStreamingPayloadTooLarge(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(respondInTurn(200, 413))
+	defer receiver.server.Close()
+	batch := "[{\"requestPath\":\"/pets\"},{\"requestPath\":\"/owners\"},{\"requestPath\":\"/\"}]"
+	persister := &MockBatchPersister{
+		batches: []string{batch},
+	}
+	publisher := &Publisher{
+		Logger:          logger,
+		SpServerUrl:     receiver.server.URL,
+		HttpClient:      &http.Client{},
+		Persister:       persister,
+		Streaming:       true,
+		MaxRequestBytes: 1,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if persister.committed != 1 {
+		t.Errorf("Expected the batch to be committed, but committed : %d", persister.committed)
+	}
+	records := streamedRecords(t, receiver, "")
+	// The first part which was accepted is not sent again along with the rest of the records
+	if len(records) != 3 || len(records[2]) != 2 || receiver.requests[2].chunked ||
+		string(records[2][0]) != "{\"requestPath\":\"/owners\"}" {
+		t.Errorf("Unexpected records in the requests : %v", records)
+		return
+	}
+	expectChunked(t, receiver.requests[:2])
+	if keys := idempotencyKeys(receiver); keys[0] != "batch-1-1" || keys[1] != "batch-1-2" {
+		t.Errorf("Unexpected idempotency keys : %v", keys)
+	}
+}
+
+func TestFetchWithStreamingInvalidBatch(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	receiver := newTestReceiver(nil)
+	defer receiver.server.Close()
+	persister := &MockBatchPersister{
+		batches: []string{"[{\"requestPath\":\"/pets\"},{\"requestPath\""},
+	}
+	publisher := &Publisher{
+		Logger:      logger,
+		SpServerUrl: receiver.server.URL,
+		HttpClient:  &http.Client{},
+		Persister:   persister,
+		Streaming:   true,
+	}
+	err = publisher.execute()
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if stats := publisher.Stats(); stats.Dropped != 1 || persister.committed != 1 {
+		t.Errorf("Expected the invalid batch to be dropped, but dropped : %d", stats.Dropped)
+	}
+}
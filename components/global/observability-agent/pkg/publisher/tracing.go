@@ -0,0 +1,283 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/model"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
+)
+
+const (
+	ZipkinProtocol = "zipkin"
+	JaegerProtocol = "jaeger"
+)
+
+const (
+	zipkinSpansPath = "/api/v2/spans"
+	jaegerSpansPath = "/api/traces"
+)
+
+type (
+	// TracingBackend is the configuration of the exporter which forwards the received spans to a tracing backend
+	TracingBackend struct {
+		// Protocol is either zipkin, to send Zipkin v2 JSON to /api/v2/spans, or jaeger, to send Jaeger Thrift
+		// batches to the /api/traces endpoint of a Jaeger collector
+		Protocol string            `json:"protocol"`
+		Endpoint string            `json:"endpoint"`
+		Headers  map[string]string `json:"headers"`
+	}
+
+	// TracingBackendExporter forwards the spans received by the tracing agent, in the Zipkin v2 format, to a Zipkin
+	// or Jaeger collector. Only the Jaeger batches of the services which failed are sent again when a batch is
+	// retried.
+	TracingBackendExporter struct {
+		Protocol   string
+		Endpoint   string
+		Headers    map[string]string
+		HttpClient *http.Client
+		Logger     *zap.SugaredLogger
+		Classifier ResponseClassifier
+
+		lock    sync.Mutex
+		pending map[string]map[string]bool
+	}
+)
+
+func NewTracingBackendExporter(config *TracingBackend, httpClient *http.Client,
+	logger *zap.SugaredLogger) (*TracingBackendExporter, error) {
+	protocol := config.Protocol
+	if protocol == "" {
+		protocol = ZipkinProtocol
+	}
+	if protocol != ZipkinProtocol && protocol != JaegerProtocol {
+		return nil, fmt.Errorf("unknown tracing backend protocol %s", config.Protocol)
+	}
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("tracing backend endpoint is empty")
+	}
+	return &TracingBackendExporter{
+		Protocol:   protocol,
+		Endpoint:   strings.TrimSuffix(config.Endpoint, "/"),
+		Headers:    config.Headers,
+		HttpClient: httpClient,
+		Logger:     logger,
+	}, nil
+}
+
+func (exporter *TracingBackendExporter) Name() string {
+	return exporter.Protocol
+}
+
+func (exporter *TracingBackendExporter) Export(id string, jsonArr string) error {
+	if exporter.Protocol == JaegerProtocol {
+		return exporter.exportToJaeger(id, jsonArr)
+	}
+	return exporter.exportToZipkin(jsonArr)
+}
+
+// exportToZipkin sends the batch as it is, since the batch already is a Zipkin v2 JSON array
+func (exporter *TracingBackendExporter) exportToZipkin(jsonArr string) error {
+	var buf bytes.Buffer
+	g := gzip.NewWriter(&buf)
+	if _, err := g.Write([]byte(jsonArr)); err != nil {
+		return fmt.Errorf("could not write to buffer : %v", err)
+	}
+	if err := g.Close(); err != nil {
+		return fmt.Errorf("could not close the gzip writer : %v", err)
+	}
+	req, err := exporter.newRequest(zipkinSpansPath, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Encoding", "gzip")
+	return doRequest(exporter.HttpClient, req, exporter.Classifier)
+}
+
+// exportToJaeger sends a Jaeger Thrift batch for each service, since a batch can only have a single process.
+// The services already sent are remembered, so that a retried batch does not send their spans again.
+func (exporter *TracingBackendExporter) exportToJaeger(id string, jsonArr string) error {
+	batches, err := toJaegerBatches(jsonArr)
+	if err != nil {
+		return &PermanentError{Reason: fmt.Sprintf("could not convert the batch to Jaeger Thrift : %v", err)}
+	}
+	done := exporter.progress(id)
+	for _, batch := range batches {
+		serviceName := batch.Process.ServiceName
+		if done[serviceName] {
+			continue
+		}
+		buf := thrift.NewTMemoryBuffer()
+		err = batch.Write(thrift.NewTBinaryProtocolTransport(buf))
+		if err != nil {
+			exporter.finish(id)
+			return &PermanentError{Reason: fmt.Sprintf("could not serialize the Jaeger batch : %v", err)}
+		}
+		req, err := exporter.newRequest(jaegerSpansPath, buf.Buffer)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/x-thrift")
+		err = doRequest(exporter.HttpClient, req, exporter.Classifier)
+		if err != nil {
+			if _, ok := err.(*PermanentError); ok {
+				exporter.finish(id)
+			}
+			return err
+		}
+		exporter.markDone(id, serviceName)
+	}
+	exporter.finish(id)
+	return nil
+}
+
+// progress returns a copy of the services of the batch which were already sent to the Jaeger collector
+func (exporter *TracingBackendExporter) progress(id string) map[string]bool {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	done := make(map[string]bool)
+	for serviceName := range exporter.pending[id] {
+		done[serviceName] = true
+	}
+	return done
+}
+
+// markDone remembers that the spans of the service in the batch were sent to the Jaeger collector
+func (exporter *TracingBackendExporter) markDone(id string, serviceName string) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	if exporter.pending == nil {
+		exporter.pending = make(map[string]map[string]bool)
+	}
+	if exporter.pending[id] == nil {
+		exporter.pending[id] = make(map[string]bool)
+	}
+	exporter.pending[id][serviceName] = true
+}
+
+func (exporter *TracingBackendExporter) finish(id string) {
+	exporter.lock.Lock()
+	defer exporter.lock.Unlock()
+	delete(exporter.pending, id)
+}
+
+func (exporter *TracingBackendExporter) newRequest(path string, body *bytes.Buffer) (*http.Request, error) {
+	req, err := http.NewRequest("POST", exporter.Endpoint+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("could not make a new request : %v", err)
+	}
+	for key, value := range exporter.Headers {
+		req.Header.Set(key, value)
+	}
+	return req, nil
+}
+
+// toJaegerBatches converts the Zipkin v2 spans into Jaeger Thrift batches grouped by the service
+func toJaegerBatches(jsonArr string) ([]*jaeger.Batch, error) {
+	var spans []tracing.ZipkinSpan
+	err := json.Unmarshal([]byte(jsonArr), &spans)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal the spans : %v", err)
+	}
+	var batches []*jaeger.Batch
+	batchesByService := make(map[string]*jaeger.Batch)
+	for _, span := range spans {
+		serviceName := ""
+		if span.LocalEndpoint != nil {
+			serviceName = span.LocalEndpoint.ServiceName
+		}
+		batch, ok := batchesByService[serviceName]
+		if !ok {
+			batch = &jaeger.Batch{
+				Process: &jaeger.Process{ServiceName: serviceName},
+			}
+			batchesByService[serviceName] = batch
+			batches = append(batches, batch)
+		}
+		jaegerSpan, err := toJaegerSpan(span)
+		if err != nil {
+			return nil, err
+		}
+		batch.Spans = append(batch.Spans, jaegerSpan)
+	}
+	return batches, nil
+}
+
+func toJaegerSpan(span tracing.ZipkinSpan) (*jaeger.Span, error) {
+	traceID, err := model.TraceIDFromString(span.TraceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace id %s : %v", span.TraceID, err)
+	}
+	spanID, err := model.SpanIDFromString(span.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid span id %s : %v", span.ID, err)
+	}
+	jaegerSpan := &jaeger.Span{
+		TraceIdLow:    int64(traceID.Low),
+		TraceIdHigh:   int64(traceID.High),
+		SpanId:        int64(spanID),
+		OperationName: span.Name,
+		Flags:         int32(model.SampledFlag),
+		StartTime:     span.Timestamp,
+		Duration:      span.Duration,
+	}
+	if span.Debug {
+		jaegerSpan.Flags |= int32(model.DebugFlag)
+	}
+	if span.ParentID != "" {
+		parentID, err := model.SpanIDFromString(span.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parent span id %s : %v", span.ParentID, err)
+		}
+		jaegerSpan.ParentSpanId = int64(parentID)
+	}
+	if span.Kind != "" {
+		jaegerSpan.Tags = append(jaegerSpan.Tags, jaegerStringTag("span.kind", strings.ToLower(span.Kind)))
+	}
+	for _, key := range sortedStringKeys(span.Tags) {
+		jaegerSpan.Tags = append(jaegerSpan.Tags, jaegerStringTag(key, span.Tags[key]))
+	}
+	for _, annotation := range span.Annotations {
+		jaegerSpan.Logs = append(jaegerSpan.Logs, &jaeger.Log{
+			Timestamp: annotation.Timestamp,
+			Fields:    []*jaeger.Tag{jaegerStringTag("event", annotation.Value)},
+		})
+	}
+	return jaegerSpan, nil
+}
+
+func jaegerStringTag(key string, value string) *jaeger.Tag {
+	return &jaeger.Tag{
+		Key:   key,
+		VType: jaeger.TagType_STRING,
+		VStr:  &value,
+	}
+}
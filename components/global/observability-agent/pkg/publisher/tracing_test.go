@@ -0,0 +1,262 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package publisher
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/thrift/lib/go/thrift"
+	"github.com/jaegertracing/jaeger/thrift-gen/jaeger"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
+)
+
+var (
+	testZipkinSpans = []tracing.ZipkinSpan{
+		{
+			TraceID:       "5af7183fb1d4cf5f0000000000000001",
+			ID:            "6b221d5bc9e6496c",
+			Kind:          "SERVER",
+			Name:          "get /pets",
+			Timestamp:     1559000000000123,
+			Duration:      20456,
+			LocalEndpoint: &tracing.ZipkinEndpoint{ServiceName: "pet-be"},
+			Annotations:   []tracing.ZipkinAnnotation{{Timestamp: 1559000000000200, Value: "retry"}},
+			Tags:          map[string]string{"http.status_code": "200", "component": "proxy"},
+		},
+		{
+			TraceID:       "5af7183fb1d4cf5f0000000000000001",
+			ParentID:      "6b221d5bc9e6496c",
+			ID:            "0000000000000002",
+			Kind:          "CLIENT",
+			Name:          "get /pets",
+			Timestamp:     1559000000001000,
+			Duration:      10000,
+			LocalEndpoint: &tracing.ZipkinEndpoint{ServiceName: "pet-fe"},
+		},
+	}
+)
+
+func marshalZipkinSpans(t *testing.T) string {
+	bytesArr, err := json.Marshal(testZipkinSpans)
+	if err != nil {
+		t.Errorf("Error when marshalling the spans : %v", err)
+	}
+	return string(bytesArr)
+}
+
+func TestTracingBackendExportToZipkin(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	jsonArr := marshalZipkinSpans(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		requests++
+		if req.URL.Path != "/api/v2/spans" || req.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request to %s with the content type %s", req.URL.Path,
+				req.Header.Get("Content-Type"))
+		}
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		var buf bytes.Buffer
+		err := decodeGzip(&buf, bytesArr)
+		if err != nil {
+			t.Errorf("Error when decoding gzip : %v", err)
+		}
+		if buf.String() != jsonArr {
+			t.Errorf("Expected the spans to be sent as they are, but received : %s", buf.String())
+		}
+		res.WriteHeader(202)
+	}))
+	defer server.Close()
+	exporter, err := NewTracingBackendExporter(&TracingBackend{Endpoint: server.URL}, &http.Client{}, logger)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+		return
+	}
+	if exporter.Name() != ZipkinProtocol {
+		t.Errorf("Expected the zipkin protocol by default, but received : %s", exporter.Name())
+	}
+	err = exporter.Export(testBatchID, jsonArr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request, but received : %d", requests)
+	}
+}
+
+func TestTracingBackendExportToJaeger(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var batches []*jaeger.Batch
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/api/traces" || req.Header.Get("Content-Type") != "application/x-thrift" {
+			t.Errorf("Unexpected request to %s with the content type %s", req.URL.Path,
+				req.Header.Get("Content-Type"))
+		}
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		buf := thrift.NewTMemoryBuffer()
+		_, _ = buf.Write(bytesArr)
+		batch := jaeger.NewBatch()
+		err := batch.Read(thrift.NewTBinaryProtocolTransport(buf))
+		if err != nil {
+			t.Errorf("Error when reading the Jaeger batch : %v", err)
+		}
+		batches = append(batches, batch)
+		res.WriteHeader(202)
+	}))
+	defer server.Close()
+	exporter, err := NewTracingBackendExporter(&TracingBackend{
+		Protocol: JaegerProtocol,
+		Endpoint: server.URL,
+	}, &http.Client{}, logger)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+		return
+	}
+	err = exporter.Export(testBatchID, marshalZipkinSpans(t))
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(batches) != 2 {
+		t.Errorf("Expected a batch for each service, but received : %d", len(batches))
+		return
+	}
+	if batches[0].Process.ServiceName != "pet-be" || batches[1].Process.ServiceName != "pet-fe" {
+		t.Errorf("Unexpected services : %s, %s", batches[0].Process.ServiceName, batches[1].Process.ServiceName)
+	}
+	span := batches[0].Spans[0]
+	if span.TraceIdHigh != 0x5af7183fb1d4cf5f || span.TraceIdLow != 1 || span.SpanId != 0x6b221d5bc9e6496c {
+		t.Errorf("Unexpected ids : %x %x %x", span.TraceIdHigh, span.TraceIdLow, span.SpanId)
+	}
+	if span.StartTime != 1559000000000123 || span.Duration != 20456 {
+		t.Errorf("Unexpected start time or duration : %d, %d", span.StartTime, span.Duration)
+	}
+	tags := make(map[string]string)
+	for _, tag := range span.Tags {
+		tags[tag.Key] = tag.GetVStr()
+	}
+	if len(tags) != 3 || tags["span.kind"] != "server" || tags["http.status_code"] != "200" {
+		t.Errorf("Unexpected tags : %v", tags)
+	}
+	if len(span.Logs) != 1 || span.Logs[0].Fields[0].GetVStr() != "retry" {
+		t.Errorf("Unexpected logs : %v", span.Logs)
+	}
+	if batches[1].Spans[0].ParentSpanId != 0x6b221d5bc9e6496c {
+		t.Errorf("Unexpected parent span id : %x", batches[1].Spans[0].ParentSpanId)
+	}
+}
+
+func TestTracingBackendExportToJaegerWithRetry(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	var services []string
+	failed := false
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		bytesArr, _ := ioutil.ReadAll(req.Body)
+		buf := thrift.NewTMemoryBuffer()
+		_, _ = buf.Write(bytesArr)
+		batch := jaeger.NewBatch()
+		err := batch.Read(thrift.NewTBinaryProtocolTransport(buf))
+		if err != nil {
+			t.Errorf("Error when reading the Jaeger batch : %v", err)
+		}
+		if batch.Process.ServiceName == "pet-fe" && !failed {
+			failed = true
+			res.WriteHeader(503)
+			return
+		}
+		services = append(services, batch.Process.ServiceName)
+		res.WriteHeader(202)
+	}))
+	defer server.Close()
+	exporter, err := NewTracingBackendExporter(&TracingBackend{
+		Protocol: JaegerProtocol,
+		Endpoint: server.URL,
+	}, &http.Client{}, logger)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+		return
+	}
+	jsonArr := marshalZipkinSpans(t)
+	err = exporter.Export(testBatchID, jsonArr)
+	if err == nil {
+		t.Error("Expected an error when the collector is unavailable, but received nil")
+	}
+	err = exporter.Export(testBatchID, jsonArr)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if len(services) != 2 || services[0] != "pet-be" || services[1] != "pet-fe" {
+		t.Errorf("Expected each service to be sent once, but received : %v", services)
+	}
+	if len(exporter.pending) != 0 {
+		t.Errorf("Expected no pending batches, but received : %d", len(exporter.pending))
+	}
+}
+
+func TestTracingBackendExportToJaegerWithInvalidSpan(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	exporter, err := NewTracingBackendExporter(&TracingBackend{
+		Protocol: JaegerProtocol,
+		Endpoint: "http://example.com",
+	}, &http.Client{}, logger)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+		return
+	}
+	err = exporter.Export(testBatchID, "[{\"traceId\":\"xyz\",\"id\":\"1\"}]")
+	if _, ok := err.(*PermanentError); !ok {
+		t.Errorf("Expected a permanent error, but received : %v", err)
+	}
+}
+
+func TestNewTracingBackendExporterWithUnknownProtocol(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	_, err = NewTracingBackendExporter(&TracingBackend{
+		Protocol: "xray",
+		Endpoint: "http://example.com",
+	}, &http.Client{}, logger)
+	expectedErr := "unknown tracing backend protocol xray"
+	if err == nil {
+		t.Errorf("An error was not thrown, but expected : %s", expectedErr)
+		return
+	}
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+}
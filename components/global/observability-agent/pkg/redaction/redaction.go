@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package redaction
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// MaskAction replaces the redacted values with the mask
+	MaskAction = "mask"
+	// HashAction replaces the redacted values with their hash, salted so that they can not be looked up, which still
+	// allows correlating the records with the same value
+	HashAction = "hash"
+
+	Mask = "[REDACTED]"
+	// AllParameters matches all the query string parameters
+	AllParameters = "*"
+)
+
+type (
+	// Redaction is the configuration of the rules redacting the sensitive values of the records
+	Redaction struct {
+		Salt  string  `json:"salt"`
+		Rules []*Rule `json:"rules"`
+	}
+
+	// Rule redacts the whole values of the keys, or only the matches of the pattern or the values of the query string
+	// parameters in them. The pattern and query parameter rules apply to the values of all the keys if no keys are
+	// given.
+	Rule struct {
+		Keys            []string `json:"keys"`
+		Pattern         string   `json:"pattern"`
+		QueryParameters []string `json:"queryParameters"`
+		Action          string   `json:"action"`
+	}
+
+	// Redactor applies the redaction rules in the given order. A nil redactor leaves the values as they are.
+	Redactor struct {
+		salt  []byte
+		rules []*rule
+	}
+
+	rule struct {
+		keys            map[string]bool
+		pattern         *regexp.Regexp
+		queryParameters map[string]bool
+		hash            bool
+	}
+)
+
+// New validates the redaction rules and builds the redactor. A nil redactor is returned if no rules are given.
+func New(config *Redaction) (*Redactor, error) {
+	if config == nil || len(config.Rules) == 0 {
+		return nil, nil
+	}
+	redactor := &Redactor{
+		salt: []byte(config.Salt),
+	}
+	for i, ruleConfig := range config.Rules {
+		rule, err := newRule(ruleConfig, config.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction rule at %d : %v", i, err)
+		}
+		redactor.rules = append(redactor.rules, rule)
+	}
+	return redactor, nil
+}
+
+// Redact redacts the string values of the attributes in place, including the values of the nested attributes
+func (redactor *Redactor) Redact(attributes map[string]interface{}) {
+	if redactor == nil {
+		return
+	}
+	for key, value := range attributes {
+		switch value := value.(type) {
+		case string:
+			attributes[key] = redactor.Value(key, value)
+		case map[string]interface{}:
+			redactor.Redact(value)
+		}
+	}
+}
+
+// Value returns the value of the key after applying the redaction rules
+func (redactor *Redactor) Value(key string, value string) string {
+	if redactor == nil {
+		return value
+	}
+	for _, rule := range redactor.rules {
+		if len(rule.keys) > 0 && !rule.keys[strings.ToLower(key)] {
+			continue
+		}
+		replace := func(value string) string {
+			if rule.hash {
+				return redactor.hash(value)
+			}
+			return Mask
+		}
+		switch {
+		case rule.pattern != nil:
+			value = rule.pattern.ReplaceAllStringFunc(value, replace)
+		case len(rule.queryParameters) > 0:
+			value = scrubQuery(value, rule.queryParameters, replace)
+		default:
+			value = replace(value)
+		}
+	}
+	return value
+}
+
+func (redactor *Redactor) hash(value string) string {
+	mac := hmac.New(sha256.New, redactor.salt)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newRule(config *Rule, salt string) (*rule, error) {
+	if config == nil {
+		return nil, fmt.Errorf("the rule is empty")
+	}
+	if len(config.Keys) == 0 && config.Pattern == "" && len(config.QueryParameters) == 0 {
+		return nil, fmt.Errorf("the rule does not have keys, a pattern or query parameters")
+	}
+	if config.Pattern != "" && len(config.QueryParameters) > 0 {
+		return nil, fmt.Errorf("the rule should have either a pattern or query parameters")
+	}
+	newRule := &rule{
+		keys:            make(map[string]bool),
+		queryParameters: make(map[string]bool),
+	}
+	switch config.Action {
+	case "", MaskAction:
+	case HashAction:
+		if salt == "" {
+			return nil, fmt.Errorf("the salt is required for hashing")
+		}
+		newRule.hash = true
+	default:
+		return nil, fmt.Errorf("unknown redaction action %s", config.Action)
+	}
+	for _, key := range config.Keys {
+		newRule.keys[strings.ToLower(key)] = true
+	}
+	for _, parameter := range config.QueryParameters {
+		newRule.queryParameters[parameter] = true
+	}
+	if config.Pattern != "" {
+		pattern, err := regexp.Compile(config.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("could not compile the pattern : %v", err)
+		}
+		newRule.pattern = pattern
+	}
+	return newRule, nil
+}
+
+// scrubQuery replaces the values of the given parameters in the query string of the value, keeping the rest of the
+// value as it is
+func scrubQuery(value string, parameters map[string]bool, replace func(string) string) string {
+	start := strings.Index(value, "?")
+	if start < 0 {
+		return value
+	}
+	end := len(value)
+	if fragment := strings.Index(value[start:], "#"); fragment >= 0 {
+		end = start + fragment
+	}
+	pairs := strings.Split(value[start+1:end], "&")
+	for i, pair := range pairs {
+		separator := strings.Index(pair, "=")
+		if separator < 0 || pair[separator+1:] == "" {
+			continue
+		}
+		if parameters[pair[:separator]] || parameters[AllParameters] {
+			pairs[i] = pair[:separator+1] + replace(pair[separator+1:])
+		}
+	}
+	return value[:start+1] + strings.Join(pairs, "&") + value[end:]
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package redaction
+
+import (
+	"reflect"
+	"testing"
+)
+
+const customerIdHash = "84dafa54f112f780841ba91a9678b6ca84c59f280b3242d87b029290662d7105"
+
+func TestRedact(t *testing.T) {
+	redactor, err := New(&Redaction{
+		Salt: "pepper",
+		Rules: []*Rule{
+			{Keys: []string{"Authorization"}},
+			{Pattern: `[\w.+-]+@[\w-]+\.[\w.]+`},
+			{Keys: []string{"requestPath", "http.url"}, QueryParameters: []string{"token", "apiKey"}},
+			{Keys: []string{"customerId"}, Action: HashAction},
+			{Keys: []string{"requestPath"}, Pattern: `c-\d+`, Action: HashAction},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the redactor : %v", err)
+	}
+	attributes := map[string]interface{}{
+		"authorization": "Bearer abc",
+		"requestPath":   "/customers/c-1042/orders?token=abc&page=2&apiKey=&email=jane@example.com#top",
+		"customerId":    "c-1042",
+		"responseCode":  200,
+		"spanTags": map[string]interface{}{
+			"http.url": "http://pet-be/pets?apiKey=xyz",
+			"user":     "jane@example.com",
+		},
+	}
+	redactor.Redact(attributes)
+	expectedAttributes := map[string]interface{}{
+		"authorization": Mask,
+		"requestPath": "/customers/" + customerIdHash + "/orders?token=" + Mask + "&page=2&apiKey=&email=" + Mask +
+			"#top",
+		"customerId":   customerIdHash,
+		"responseCode": 200,
+		"spanTags": map[string]interface{}{
+			"http.url": "http://pet-be/pets?apiKey=" + Mask,
+			"user":     Mask,
+		},
+	}
+	if !reflect.DeepEqual(attributes, expectedAttributes) {
+		t.Errorf("Expected the attributes %v, but received %v", expectedAttributes, attributes)
+	}
+}
+
+func TestValueWithAllQueryParameters(t *testing.T) {
+	redactor, err := New(&Redaction{
+		Rules: []*Rule{{QueryParameters: []string{AllParameters}}},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the redactor : %v", err)
+	}
+	tests := []struct {
+		value         string
+		expectedValue string
+	}{
+		{value: "/pets", expectedValue: "/pets"},
+		{value: "/pets?", expectedValue: "/pets?"},
+		{value: "/pets?id=1&debug", expectedValue: "/pets?id=" + Mask + "&debug"},
+	}
+	for _, test := range tests {
+		value := redactor.Value("requestPath", test.value)
+		if value != test.expectedValue {
+			t.Errorf("Expected the value %s, but received %s", test.expectedValue, value)
+		}
+	}
+}
+
+func TestNilRedactor(t *testing.T) {
+	redactor, err := New(&Redaction{Salt: "pepper"})
+	if err != nil {
+		t.Fatalf("Unexpected error : %v", err)
+	}
+	if redactor != nil {
+		t.Errorf("Expected a nil redactor when no rules are given")
+	}
+	attributes := map[string]interface{}{"requestPath": "/pets?token=abc"}
+	redactor.Redact(attributes)
+	if attributes["requestPath"] != "/pets?token=abc" {
+		t.Errorf("Expected the attributes to be unchanged, but received %v", attributes)
+	}
+}
+
+func TestNewWithInvalidRules(t *testing.T) {
+	tests := []struct {
+		config      *Redaction
+		expectedErr string
+	}{
+		{config: &Redaction{Rules: []*Rule{nil}}, expectedErr: "invalid redaction rule at 0 : the rule is empty"},
+		{config: &Redaction{Rules: []*Rule{{Action: MaskAction}}},
+			expectedErr: "invalid redaction rule at 0 : the rule does not have keys, a pattern or query parameters"},
+		{config: &Redaction{Rules: []*Rule{{Pattern: "a", QueryParameters: []string{"token"}}}},
+			expectedErr: "invalid redaction rule at 0 : the rule should have either a pattern or query parameters"},
+		{config: &Redaction{Rules: []*Rule{{Keys: []string{"customerId"}, Action: HashAction}}},
+			expectedErr: "invalid redaction rule at 0 : the salt is required for hashing"},
+		{config: &Redaction{Rules: []*Rule{{Keys: []string{"customerId"}, Action: "encrypt"}}},
+			expectedErr: "invalid redaction rule at 0 : unknown redaction action encrypt"},
+		{config: &Redaction{Rules: []*Rule{{Pattern: "("}}},
+			expectedErr: "invalid redaction rule at 0 : could not compile the pattern : error parsing regexp: " +
+				"missing closing ): `(`"},
+	}
+	for _, test := range tests {
+		_, err := New(test.config)
+		if err == nil || err.Error() != test.expectedErr {
+			t.Errorf("Expected the error %s, but received %v", test.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,418 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scrape_receiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/processor"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+)
+
+const (
+	defaultMetricsPath     = "/stats/prometheus"
+	defaultIntervalSeconds = 15
+	defaultTimeoutSeconds  = 10
+
+	// InstanceNameKey and ValueKey are the keys of a record holding the name of the series and its value, which
+	// match the records of the metrics received by the Mixer adapter
+	InstanceNameKey = "instanceName"
+	ValueKey        = "value"
+	targetKey       = "scrapeTarget"
+
+	reporterLabel = "reporter"
+)
+
+var (
+	// defaultMetrics are the request metrics exposed by the Istio proxies
+	defaultMetrics = []string{
+		"istio_requests_total",
+		"istio_request_duration_milliseconds",
+		"istio_request_bytes",
+		"istio_response_bytes",
+	}
+
+	// attributeNames maps the labels of the Istio metrics to the attributes of the telemetry records. The other
+	// labels are kept as they are.
+	attributeNames = map[string]string{
+		"source_workload_namespace":      "sourceNamespace",
+		"source_workload":                "sourceWorkload",
+		"source_app":                     "sourceComponent",
+		"destination_workload_namespace": "destinationNamespace",
+		"destination_workload":           "destinationWorkload",
+		"destination_app":                "destinationComponent",
+		"destination_service":            "destinationService",
+		"request_protocol":               "requestProtocol",
+		"response_code":                  "responseCode",
+	}
+
+	// reporterKinds maps the reporter label of the Istio metrics to the context reporter kind of Mixer
+	reporterKinds = map[string]string{
+		"source":      "outbound",
+		"destination": "inbound",
+	}
+)
+
+type (
+	// Scrape is the configuration of the receiver which scrapes the Prometheus metrics of the Envoy proxies. The
+	// Istio metrics do not identify the source and destination pods, which the telemetry app of SP requires, and their
+	// values are deltas rather than single requests, hence the scraped records are only written to a named pipeline
+	// and never to the metric buffer.
+	Scrape struct {
+		// Targets are the addresses scraped in addition to the ones in the targets file
+		Targets []string `json:"targets"`
+		// TargetsFile is a list of target groups in the JSON format of the Prometheus file based service discovery,
+		// which is read before each scrape
+		TargetsFile     string   `json:"targetsFile"`
+		MetricsPath     string   `json:"metricsPath"`
+		IntervalSeconds int      `json:"intervalSeconds"`
+		TimeoutSeconds  int      `json:"timeoutSeconds"`
+		Metrics         []string `json:"metrics"`
+		// Pipeline is the named pipeline to which the scraped records are written
+		Pipeline string `json:"pipeline"`
+	}
+
+	// targetGroup is an entry of the targets file
+	targetGroup struct {
+		Targets []string          `json:"targets"`
+		Labels  map[string]string `json:"labels"`
+	}
+
+	// Receiver scrapes the targets periodically and writes a record for each selected series to the buffer. The
+	// value of the counters, histograms and summaries is the increase since the previous scrape, hence a series
+	// is written only from its second scrape onwards, and only if it increased. The records are redacted and
+	// processed in the same way as the records received from Mixer, and the overflow handler decides what happens
+	// to the records written to a full buffer.
+	Receiver struct {
+		Config     *Scrape
+		HttpClient *http.Client
+		Logger     *zap.SugaredLogger
+		Buffer     chan string
+		Overflow   *overflow.Handler
+		Redactor   *redaction.Redactor
+		Processors *processor.Chain
+		metrics    map[string]bool
+		lock       sync.Mutex
+		// previous holds the last value of each series of each target
+		previous map[string]map[string]float64
+	}
+
+	// sample is a single value of a metric, which is cumulative for the counters, histograms and summaries
+	sample struct {
+		name       string
+		value      float64
+		cumulative bool
+	}
+)
+
+// PipelineBuffer returns the buffer of the pipeline of the scraped records
+func (config *Scrape) PipelineBuffer(pipelines map[string]chan string) (chan string, error) {
+	if config.Pipeline == "" {
+		return nil, fmt.Errorf("the pipeline of the scraped records is not set")
+	}
+	buffer := pipelines[config.Pipeline]
+	if buffer == nil {
+		return nil, fmt.Errorf("unknown pipeline %s", config.Pipeline)
+	}
+	return buffer, nil
+}
+
+func New(config *Scrape, httpClient *http.Client, logger *zap.SugaredLogger, buffer chan string,
+	overflowHandler *overflow.Handler, redactor *redaction.Redactor, processors *processor.Chain) *Receiver {
+	metrics := config.Metrics
+	if len(metrics) == 0 {
+		metrics = defaultMetrics
+	}
+	receiver := &Receiver{
+		Config:     config,
+		HttpClient: httpClient,
+		Logger:     logger,
+		Buffer:     buffer,
+		Overflow:   overflowHandler,
+		Redactor:   redactor,
+		Processors: processors,
+		metrics:    make(map[string]bool, len(metrics)),
+		previous:   make(map[string]map[string]float64),
+	}
+	for _, metric := range metrics {
+		receiver.metrics[metric] = true
+	}
+	return receiver
+}
+
+func (receiver *Receiver) Run(stopCh <-chan struct{}) {
+	receiver.Logger.Info("Scrape receiver started")
+	interval := time.Duration(receiver.Config.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultIntervalSeconds * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		receiver.scrape()
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// scrape scrapes all the targets concurrently
+func (receiver *Receiver) scrape() {
+	groups, err := receiver.targetGroups()
+	if err != nil {
+		receiver.Logger.Errorf("Could not read the targets : %v", err)
+		return
+	}
+	var waitGroup sync.WaitGroup
+	seen := make(map[string]bool)
+	for _, group := range groups {
+		for _, target := range group.Targets {
+			if seen[target] {
+				continue
+			}
+			seen[target] = true
+			waitGroup.Add(1)
+			go func(target string, labels map[string]string) {
+				defer waitGroup.Done()
+				err := receiver.scrapeTarget(target, labels)
+				if err != nil {
+					receiver.Logger.Warnf("Could not scrape the target %s : %v", target, err)
+				}
+			}(target, group.Labels)
+		}
+	}
+	waitGroup.Wait()
+	// The targets which are no longer discovered are forgotten, so that they start over if they come back
+	receiver.lock.Lock()
+	for target := range receiver.previous {
+		if !seen[target] {
+			delete(receiver.previous, target)
+		}
+	}
+	receiver.lock.Unlock()
+}
+
+func (receiver *Receiver) targetGroups() ([]targetGroup, error) {
+	groups := []targetGroup{{Targets: receiver.Config.Targets}}
+	if receiver.Config.TargetsFile == "" {
+		return groups, nil
+	}
+	data, err := ioutil.ReadFile(receiver.Config.TargetsFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the targets file : %v", err)
+	}
+	var fileGroups []targetGroup
+	err = json.Unmarshal(data, &fileGroups)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal the targets file : %v", err)
+	}
+	return append(groups, fileGroups...), nil
+}
+
+func (receiver *Receiver) scrapeTarget(target string, labels map[string]string) error {
+	families, err := receiver.fetch(target)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]float64)
+	var records []map[string]interface{}
+	receiver.lock.Lock()
+	previous, scrapedBefore := receiver.previous[target]
+	receiver.lock.Unlock()
+	for _, name := range sortedFamilyNames(families) {
+		family := families[name]
+		if !receiver.metrics[name] {
+			continue
+		}
+		for _, metric := range family.Metric {
+			for _, sample := range samples(family, metric) {
+				key := seriesKey(sample.name, metric.Label)
+				current[key] = sample.value
+				value := sample.value
+				if sample.cumulative {
+					last, ok := previous[key]
+					if !ok {
+						// The increase is known only from the next scrape onwards, unless the series appeared
+						// after the target was first scraped
+						if !scrapedBefore {
+							continue
+						}
+						last = 0
+					}
+					value = delta(last, sample.value)
+					if value == 0 {
+						continue
+					}
+				}
+				records = append(records, toRecord(target, labels, sample.name, metric.Label, value))
+			}
+		}
+	}
+	receiver.lock.Lock()
+	receiver.previous[target] = current
+	receiver.lock.Unlock()
+	for _, record := range records {
+		receiver.Redactor.Redact(record)
+		receiver.Processors.Process(record)
+		jsonValue, err := json.Marshal(record)
+		if err != nil {
+			receiver.Logger.Errorf("Could not marshal the record : %v", err)
+			continue
+		}
+		err = receiver.Overflow.Put(receiver.Buffer, string(jsonValue))
+		if err != nil {
+			receiver.Logger.Debugf("Could not buffer the scraped record : %v", err)
+		}
+	}
+	return nil
+}
+
+func (receiver *Receiver) fetch(target string) (map[string]*dto.MetricFamily, error) {
+	metricsPath := receiver.Config.MetricsPath
+	if metricsPath == "" {
+		metricsPath = defaultMetricsPath
+	}
+	url := target + metricsPath
+	if !strings.Contains(target, "://") {
+		url = "http://" + url
+	}
+	timeout := time.Duration(receiver.Config.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeoutSeconds * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create the request : %v", err)
+	}
+	req.Header.Set("Accept", string(expfmt.FmtText))
+	res, err := receiver.HttpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("could not send the request : %v", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", res.StatusCode)
+	}
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the metrics : %v", err)
+	}
+	return families, nil
+}
+
+// samples returns the values of a metric, which are the sum and the count of the histograms and the summaries
+func samples(family *dto.MetricFamily, metric *dto.Metric) []sample {
+	name := family.GetName()
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		return []sample{{name: name, value: metric.GetCounter().GetValue(), cumulative: true}}
+	case dto.MetricType_GAUGE:
+		return []sample{{name: name, value: metric.GetGauge().GetValue()}}
+	case dto.MetricType_HISTOGRAM:
+		return []sample{
+			{name: name + "_sum", value: metric.GetHistogram().GetSampleSum(), cumulative: true},
+			{name: name + "_count", value: float64(metric.GetHistogram().GetSampleCount()), cumulative: true},
+		}
+	case dto.MetricType_SUMMARY:
+		return []sample{
+			{name: name + "_sum", value: metric.GetSummary().GetSampleSum(), cumulative: true},
+			{name: name + "_count", value: float64(metric.GetSummary().GetSampleCount()), cumulative: true},
+		}
+	default:
+		return []sample{{name: name, value: metric.GetUntyped().GetValue()}}
+	}
+}
+
+// delta returns the increase of a cumulative value, treating a decrease as a restart of the proxy
+func delta(last float64, value float64) float64 {
+	if value < last {
+		return value
+	}
+	return value - last
+}
+
+func toRecord(target string, targetLabels map[string]string, name string, labels []*dto.LabelPair,
+	value float64) map[string]interface{} {
+	record := make(map[string]interface{}, len(targetLabels)+len(labels)+3)
+	for key, labelValue := range targetLabels {
+		record[key] = labelValue
+	}
+	for _, label := range labels {
+		key, labelValue := label.GetName(), label.GetValue()
+		if key == reporterLabel {
+			if kind, ok := reporterKinds[labelValue]; ok {
+				record["contextReporterKind"] = kind
+				continue
+			}
+		}
+		if attribute, ok := attributeNames[key]; ok {
+			key = attribute
+		}
+		if key == "responseCode" {
+			if code, err := strconv.ParseInt(labelValue, 10, 64); err == nil {
+				record[key] = code
+				continue
+			}
+		}
+		record[key] = labelValue
+	}
+	record[targetKey] = target
+	record[InstanceNameKey] = name
+	record[ValueKey] = value
+	return record
+}
+
+func seriesKey(name string, labels []*dto.LabelPair) string {
+	pairs := make([]string, len(labels))
+	for i, label := range labels {
+		pairs[i] = label.GetName() + "=" + strconv.Quote(label.GetValue())
+	}
+	sort.Strings(pairs)
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedFamilyNames(families map[string]*dto.MetricFamily) []string {
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
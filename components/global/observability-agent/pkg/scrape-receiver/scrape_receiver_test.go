@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package scrape_receiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/processor"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+)
+
+const (
+	firstScrape = `# HELP istio_requests_total istio_requests_total
+# TYPE istio_requests_total counter
+istio_requests_total{reporter="source",source_workload_namespace="default",destination_app="stock",response_code="200"} 10
+istio_requests_total{reporter="source",source_workload_namespace="default",destination_app="stock",response_code="404"} 2
+# HELP istio_request_duration_milliseconds istio_request_duration_milliseconds
+# TYPE istio_request_duration_milliseconds histogram
+istio_request_duration_milliseconds_bucket{reporter="source",destination_app="stock",le="100"} 10
+istio_request_duration_milliseconds_bucket{reporter="source",destination_app="stock",le="+Inf"} 10
+istio_request_duration_milliseconds_sum{reporter="source",destination_app="stock"} 250
+istio_request_duration_milliseconds_count{reporter="source",destination_app="stock"} 10
+# HELP envoy_server_uptime envoy_server_uptime
+# TYPE envoy_server_uptime gauge
+envoy_server_uptime 120
+`
+	secondScrape = `# TYPE istio_requests_total counter
+istio_requests_total{reporter="source",source_workload_namespace="default",destination_app="stock",response_code="200"} 14
+istio_requests_total{reporter="source",source_workload_namespace="default",destination_app="stock",response_code="404"} 2
+istio_requests_total{reporter="source",source_workload_namespace="default",destination_app="stock",response_code="500"} 1
+# TYPE istio_request_duration_milliseconds histogram
+istio_request_duration_milliseconds_bucket{reporter="source",destination_app="stock",le="100"} 3
+istio_request_duration_milliseconds_bucket{reporter="source",destination_app="stock",le="+Inf"} 3
+istio_request_duration_milliseconds_sum{reporter="source",destination_app="stock"} 30
+istio_request_duration_milliseconds_count{reporter="source",destination_app="stock"} 3
+`
+)
+
+func readRecords(t *testing.T, buffer chan string) map[string]map[string]interface{} {
+	records := make(map[string]map[string]interface{})
+	for len(buffer) > 0 {
+		record := make(map[string]interface{})
+		err := json.Unmarshal([]byte(<-buffer), &record)
+		if err != nil {
+			t.Errorf("Could not unmarshal the record : %v", err)
+			continue
+		}
+		records[fmt.Sprintf("%v %v", record[InstanceNameKey], record["responseCode"])] = record
+	}
+	return records
+}
+
+func TestScrape(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	responses := []string{firstScrape, secondScrape}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != defaultMetricsPath {
+			t.Errorf("Unexpected request to %s", req.URL.Path)
+		}
+		_, _ = res.Write([]byte(responses[0]))
+		responses = responses[1:]
+	}))
+	defer server.Close()
+	target := strings.TrimPrefix(server.URL, "http://")
+
+	redactor, err := redaction.New(&redaction.Redaction{
+		Rules: []*redaction.Rule{{Keys: []string{"sourceNamespace"}}},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the redactor : %v", err)
+	}
+	processors, err := processor.New([]*processor.Processor{
+		{Action: processor.SetAction, Key: "cluster", Value: "dev"},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the processors : %v", err)
+	}
+	buffer := make(chan string, 100)
+	receiver := New(&Scrape{Targets: []string{target}}, &http.Client{}, logger, buffer, nil, redactor, processors)
+	receiver.scrape()
+	// Only the counters and histograms are selected by default, which need a previous scrape
+	if len(buffer) != 0 {
+		t.Errorf("Expected no records from the first scrape, but received %d", len(buffer))
+	}
+
+	receiver.scrape()
+	records := readRecords(t, buffer)
+	// The series which did not increase since the previous scrape are not written
+	expectedValues := map[string]float64{
+		"istio_requests_total 200":                        4,
+		"istio_requests_total 500":                        1,
+		"istio_request_duration_milliseconds_sum <nil>":   30,
+		"istio_request_duration_milliseconds_count <nil>": 3,
+	}
+	if len(records) != len(expectedValues) {
+		t.Errorf("Expected %d records, but received %v", len(expectedValues), records)
+	}
+	for key, expectedValue := range expectedValues {
+		record, ok := records[key]
+		if !ok {
+			t.Errorf("Expected a record for %s", key)
+			continue
+		}
+		if record[ValueKey] != expectedValue {
+			t.Errorf("Expected the value %v for %s, but received %v", expectedValue, key, record[ValueKey])
+		}
+		if record["contextReporterKind"] != "outbound" || record["destinationComponent"] != "stock" ||
+			record[targetKey] != target || record["cluster"] != "dev" {
+			t.Errorf("Unexpected attributes of %s : %v", key, record)
+		}
+	}
+	if records["istio_requests_total 200"]["sourceNamespace"] != redaction.Mask {
+		t.Errorf("Expected the source namespace to be redacted, but received %v", records["istio_requests_total 200"])
+	}
+}
+
+func TestScrapeWithTargetsFile(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/metrics" {
+			t.Errorf("Unexpected request to %s", req.URL.Path)
+		}
+		_, _ = res.Write([]byte(firstScrape))
+	}))
+	defer server.Close()
+	dir, err := ioutil.TempDir("", "scrape")
+	if err != nil {
+		t.Fatalf("Could not create the directory : %v", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	targetsFile := filepath.Join(dir, "targets.json")
+	targets := fmt.Sprintf(`[{"targets":["%s"],"labels":{"cluster":"east"}}]`, server.URL)
+	err = ioutil.WriteFile(targetsFile, []byte(targets), 0644)
+	if err != nil {
+		t.Fatalf("Could not write the targets file : %v", err)
+	}
+
+	buffer := make(chan string, 100)
+	receiver := New(&Scrape{
+		TargetsFile: targetsFile,
+		MetricsPath: "/metrics",
+		Metrics:     []string{"envoy_server_uptime"},
+	}, &http.Client{}, logger, buffer, nil, nil, nil)
+	receiver.scrape()
+	records := readRecords(t, buffer)
+	record, ok := records["envoy_server_uptime <nil>"]
+	if len(records) != 1 || !ok {
+		t.Fatalf("Expected only the gauge to be received, but received %v", records)
+	}
+	if record[ValueKey] != float64(120) || record["cluster"] != "east" {
+		t.Errorf("Unexpected record %v", record)
+	}
+
+	err = ioutil.WriteFile(targetsFile, []byte(`[]`), 0644)
+	if err != nil {
+		t.Fatalf("Could not write the targets file : %v", err)
+	}
+	receiver.scrape()
+	if len(buffer) != 0 || len(receiver.previous) != 0 {
+		t.Errorf("Expected the removed target to be forgotten, but %d targets remain", len(receiver.previous))
+	}
+}
+
+func TestDelta(t *testing.T) {
+	if value := delta(10, 14); value != 4 {
+		t.Errorf("Expected the increase 4, but received %v", value)
+	}
+	// A counter which decreased was reset by a restart of the proxy
+	if value := delta(10, 3); value != 3 {
+		t.Errorf("Expected the increase 3 after a reset, but received %v", value)
+	}
+}
+
+func TestPipelineBuffer(t *testing.T) {
+	scrapeBuffer := make(chan string, 1)
+	pipelines := map[string]chan string{"scraped": scrapeBuffer}
+	// The scraped records are never written to the metric buffer, which is published to the SP telemetry stream
+	for _, config := range []*Scrape{{}, {Pipeline: "unknown"}} {
+		buffer, err := config.PipelineBuffer(pipelines)
+		if err == nil || buffer != nil {
+			t.Errorf("Expected an error for the pipeline %q, but received the buffer %v", config.Pipeline, buffer)
+		}
+	}
+	buffer, err := (&Scrape{Pipeline: "scraped"}).PipelineBuffer(pipelines)
+	if err != nil {
+		t.Errorf("Unexpected error occurred : %v", err)
+	}
+	if buffer != scrapeBuffer {
+		t.Errorf("Expected the buffer of the pipeline, but received %v", buffer)
+	}
+}
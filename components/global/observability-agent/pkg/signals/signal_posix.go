@@ -1,3 +1,6 @@
+//go:build !windows
+// +build !windows
+
 /*
  * Copyright (c) 2018 WSO2 Inc. (http:www.wso2.org) All Rights Reserved.
  *
@@ -16,8 +19,6 @@
  * under the License.
  */
 
-// +build !windows
-
 package signals
 
 import (
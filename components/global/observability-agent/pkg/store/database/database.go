@@ -21,6 +21,7 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"sync"
 
 	"github.com/go-sql-driver/mysql"
 	"go.uber.org/zap"
@@ -32,9 +33,15 @@ type (
 	Persister struct {
 		logger *zap.SugaredLogger
 		db     *sql.DB
+		table  string
+		lock   sync.Mutex
+		// skipLockedUnsupported is set once the database rejects SKIP LOCKED, which is supported from MySQL 8.0
+		skipLockedUnsupported bool
 	}
 	Transaction struct {
 		Tx *sql.Tx
+		// RowID is the auto incremented id of the fetched row
+		RowID string
 	}
 
 	Database struct {
@@ -44,9 +51,21 @@ type (
 		Username string `json:"username"`
 		Password string `json:"password"`
 		Name     string `json:"name"`
+		// Table is the table used for the persistence, which defaults to persistence
+		Table string `json:"table"`
 	}
 )
 
+const (
+	defaultTable = "persistence"
+	// syntaxErrorNumber is the MySQL error number of a syntax error
+	syntaxErrorNumber = 1064
+)
+
+func (transaction *Transaction) ID() string {
+	return transaction.RowID
+}
+
 func (transaction *Transaction) Commit() error {
 	e := transaction.Tx.Commit()
 	if e != nil {
@@ -65,7 +84,7 @@ func (transaction *Transaction) Rollback() error {
 
 func (persister *Persister) Write(str string) error {
 	err := persister.doTransaction(func(tx *sql.Tx) error {
-		_, err := tx.Exec("INSERT INTO persistence(data) VALUES (?)", str)
+		_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s(data) VALUES (?)", persister.tableName()), str)
 		if err != nil {
 			return fmt.Errorf("could not insert the metrics to the database : %v", err)
 		}
@@ -77,6 +96,10 @@ func (persister *Persister) Write(str string) error {
 	return nil
 }
 
+// Fetch locks and deletes the oldest row in a transaction which is committed once the batch is published. The rows
+// locked by the other workers are skipped, so that the workers publish different batches concurrently instead of
+// waiting on the same row. Databases older than MySQL 8.0 do not support skipping the locked rows, hence the
+// workers fetch the rows one at a time with them.
 func (persister *Persister) Fetch() (string, store.Transaction, error) {
 	tx, err := persister.db.Begin()
 	defer persister.catchPanic(tx)
@@ -84,7 +107,13 @@ func (persister *Persister) Fetch() (string, store.Transaction, error) {
 		return "", &Transaction{}, fmt.Errorf("could not begin the transaction : %v", err)
 	}
 	transaction := &Transaction{Tx: tx}
-	rows, err := tx.Query("SELECT id,data FROM persistence LIMIT 1 FOR UPDATE")
+	rows, err := tx.Query(persister.selectQuery())
+	if mysqlErr, ok := err.(*mysql.MySQLError); ok && mysqlErr.Number == syntaxErrorNumber &&
+		persister.disableSkipLocked() {
+		persister.logger.Warn("The database does not support SKIP LOCKED, hence the batches are fetched one at a " +
+			"time regardless of the number of workers")
+		rows, err = tx.Query(persister.selectQuery())
+	}
 	if err != nil {
 		return "", transaction, fmt.Errorf("could not fetch rows from the database : %v", err)
 	}
@@ -102,13 +131,48 @@ func (persister *Persister) Fetch() (string, store.Transaction, error) {
 	if jsonArr == "" || jsonArr == "[]" {
 		return "", transaction, nil
 	}
-	_, err = tx.Exec("DELETE FROM persistence WHERE id = ?", id)
+	_, err = tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", persister.tableName()), id)
 	if err != nil {
 		return "", transaction, fmt.Errorf("could not delete the Rows : %v", err)
 	}
+	transaction.RowID = id
 	return jsonArr, transaction, nil
 }
 
+// Ordered returns true since the rows are fetched in the order of the auto incremented id
+func (persister *Persister) Ordered() bool {
+	return true
+}
+
+// selectQuery returns the query locking the oldest row which is not locked by another worker if supported
+func (persister *Persister) selectQuery() string {
+	persister.lock.Lock()
+	defer persister.lock.Unlock()
+	query := fmt.Sprintf("SELECT id,data FROM %s ORDER BY id LIMIT 1 FOR UPDATE", persister.tableName())
+	if persister.skipLockedUnsupported {
+		return query
+	}
+	return query + " SKIP LOCKED"
+}
+
+// disableSkipLocked stops using SKIP LOCKED and returns true if it was used before
+func (persister *Persister) disableSkipLocked() bool {
+	persister.lock.Lock()
+	defer persister.lock.Unlock()
+	if persister.skipLockedUnsupported {
+		return false
+	}
+	persister.skipLockedUnsupported = true
+	return true
+}
+
+func (persister *Persister) tableName() string {
+	if persister.table == "" {
+		return defaultTable
+	}
+	return persister.table
+}
+
 func (persister *Persister) catchPanic(tx *sql.Tx) {
 	if p := recover(); p != nil {
 		persister.logger.Infof("There was a panic in the process : %s", p)
@@ -167,14 +231,15 @@ func NewPersister(dbConfig *Database, logger *zap.SugaredLogger) (*Persister, er
 	if db == nil {
 		return nil, fmt.Errorf("could not create the db struct")
 	}
-	_, err = db.Exec("CREATE TABLE IF NOT EXISTS `persistence` (`id` int NOT NULL AUTO_INCREMENT, `data`" +
-		" longtext NOT NULL, PRIMARY KEY (`id`))")
-	if err != nil {
-		return nil, fmt.Errorf("could not create the table : %v", err)
-	}
 	ps := &Persister{
 		db:     db,
 		logger: logger,
+		table:  dbConfig.Table,
+	}
+	_, err = db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` (`id` int NOT NULL AUTO_INCREMENT, `data`"+
+		" longtext NOT NULL, PRIMARY KEY (`id`))", ps.tableName()))
+	if err != nil {
+		return nil, fmt.Errorf("could not create the table : %v", err)
 	}
 	return ps, nil
 }
@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
 )
@@ -296,7 +297,7 @@ func TestFetchWithSuccessfulFetch(t *testing.T) {
 	rows := sqlmock.NewRows([]string{"id", "data"}).
 		AddRow(1, testStr)
 	mock.ExpectBegin()
-	mock.ExpectQuery("^SELECT (.+) FROM persistence*").
+	mock.ExpectQuery("^SELECT id,data FROM persistence ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED$").
 		WillReturnRows(rows)
 	mock.ExpectExec("^DELETE FROM persistence*").
 		WillReturnResult(sqlmock.NewResult(2, 2))
@@ -316,6 +317,81 @@ func TestFetchWithSuccessfulFetch(t *testing.T) {
 	}
 	if tx == nil {
 		t.Error("Received an empty transaction struct")
+	} else if tx.ID() != "1" {
+		t.Errorf("Expected the id of the row as the ID, but received : %s", tx.ID())
+	}
+}
+
+func TestFetchWithoutSkipLocked(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("An error when opening a stub database connection : %v ", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("^SELECT id,data FROM persistence ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED$").
+		WillReturnError(&mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"})
+	mock.ExpectQuery("^SELECT id,data FROM persistence ORDER BY id LIMIT 1 FOR UPDATE$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "data"}).AddRow(1, testStr))
+	mock.ExpectExec("^DELETE FROM persistence*").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// The following fetches do not try SKIP LOCKED again
+	mock.ExpectBegin()
+	mock.ExpectQuery("^SELECT id,data FROM persistence ORDER BY id LIMIT 1 FOR UPDATE$").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "data"}))
+	persister := &Persister{
+		logger: logger,
+		db:     db,
+	}
+	str, _, err := persister.Fetch()
+	if err != nil {
+		t.Errorf("An unexpected error received : %v", err)
+	}
+	if str != testStr {
+		t.Errorf("Expected the stored batch, but received : %s", str)
+	}
+	str, _, err = persister.Fetch()
+	if err != nil || str != "" {
+		t.Errorf("Expected an empty batch, but received : %s, error : %v", str, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There are unfulfilled expectations: %v", err)
+	}
+}
+
+func TestFetchWithCustomTable(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Errorf("An error when opening a stub database connection : %v ", err)
+	}
+	rows := sqlmock.NewRows([]string{"id", "data"}).
+		AddRow(1, testStr)
+	mock.ExpectBegin()
+	mock.ExpectQuery("^SELECT (.+) FROM forwarded_spans*").
+		WillReturnRows(rows)
+	mock.ExpectExec("^DELETE FROM forwarded_spans*").
+		WillReturnResult(sqlmock.NewResult(2, 2))
+	persister := &Persister{
+		logger: logger,
+		db:     db,
+		table:  "forwarded_spans",
+	}
+	str, _, err := persister.Fetch()
+	if err != nil {
+		t.Errorf("An unexpected error received : %v", err)
+	}
+	if str != testStr {
+		t.Errorf("Expected %s, but received : %s", testStr, str)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There are unfulfilled expectations: %v", err)
 	}
 }
 
@@ -24,6 +24,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gofrs/flock"
 	"github.com/rs/xid"
@@ -45,6 +46,14 @@ type (
 	}
 )
 
+// ID returns the name of the file of the batch, which is assigned when the batch is written
+func (transaction *Transaction) ID() string {
+	if transaction.Lock == nil {
+		return ""
+	}
+	return strings.TrimSuffix(filepath.Base(transaction.Lock.String()), ".json")
+}
+
 func (transaction *Transaction) Commit() error {
 	err := os.Remove(transaction.Lock.String())
 	if err != nil {
@@ -53,7 +62,11 @@ func (transaction *Transaction) Commit() error {
 	return nil
 }
 
+// Rollback unlocks the file, if a file was locked by the fetch
 func (transaction *Transaction) Rollback() error {
+	if transaction.Lock == nil {
+		return nil
+	}
 	err := transaction.Lock.Unlock()
 	if err != nil {
 		return fmt.Errorf("could not unlock the file")
@@ -95,31 +108,60 @@ func (persister *Persister) unlock(flock *flock.Flock) {
 }
 
 func (persister *Persister) Fetch() (string, store.Transaction, error) {
+	transaction, err := persister.lockNext()
+	if err != nil || transaction.Lock == nil {
+		return "", transaction, err
+	}
+	return persister.read(transaction)
+}
+
+// FetchReader locks a file in the same way as Fetch, but returns the opened file instead of its content
+func (persister *Persister) FetchReader() (store.BatchReader, store.Transaction, error) {
+	transaction, err := persister.lockNext()
+	if err != nil || transaction.Lock == nil {
+		return nil, transaction, err
+	}
+	info, err := os.Stat(transaction.Lock.String())
+	if err != nil {
+		return nil, transaction, fmt.Errorf("could not read the file : %v", err)
+	}
+	if info.Size() == 0 {
+		err = os.Remove(transaction.Lock.String())
+		persister.logger.Debugf("Could not remove the empty file : %v", err)
+		return nil, transaction, fmt.Errorf("file is empty, hence removed")
+	}
+	reader, err := os.Open(transaction.Lock.String())
+	if err != nil {
+		return nil, transaction, fmt.Errorf("could not open the file : %v", err)
+	}
+	return reader, transaction, nil
+}
+
+// lockNext locks a file which is not locked by another publisher. The returned transaction does not have a lock if
+// there are no such files.
+func (persister *Persister) lockNext() (*Transaction, error) {
 	files, err := filepath.Glob(persister.directory + "/*.json")
 	if err != nil {
-		return "", &Transaction{}, fmt.Errorf("could not read the given directory %s : %v", persister.directory,
-			err)
+		return &Transaction{}, fmt.Errorf("could not read the given directory %s : %v", persister.directory, err)
 	}
 	persister.logger.Debugf("Files in the directory : %s", files)
-	if len(files) > 0 {
+	// Files are tried in a random order, so that concurrent publishers do not compete for the same file
+	for _, i := range rand.Perm(len(files)) {
 		transaction := &Transaction{
-			Lock: flock.New(files[rand.Intn(len(files))]),
+			Lock: flock.New(files[i]),
+		}
+		locked, err := transaction.Lock.TryLock()
+		if err != nil {
+			return transaction, fmt.Errorf("could not lock the file : %v", err)
+		}
+		if locked {
+			return transaction, nil
 		}
-		return persister.read(transaction)
-	} else {
-		return "", &Transaction{}, nil
 	}
+	return &Transaction{}, nil
 }
 
 func (persister *Persister) read(transaction *Transaction) (string, *Transaction, error) {
-	locked, err := transaction.Lock.TryLock()
-	if err != nil {
-		return "", transaction, fmt.Errorf("could not lock the file : %v", err)
-	}
-	if !locked {
-		return "", transaction, fmt.Errorf("could not achieve the lock")
-	}
-
 	data, err := ioutil.ReadFile(transaction.Lock.String())
 	if err != nil {
 		return "", transaction, fmt.Errorf("could not read the file : %v", err)
@@ -88,10 +88,13 @@ func TestFetchWithoutErrors(t *testing.T) {
 		directory: "./",
 	}
 	_ = ioutil.WriteFile("./test.json", []byte(testStr), 0644)
-	str, _, _ := persister.Fetch()
+	str, transaction, _ := persister.Fetch()
 	if str != testStr {
 		t.Error("Contents are not equal")
 	}
+	if transaction.ID() != "test" {
+		t.Errorf("Expected the name of the file as the ID, but received : %s", transaction.ID())
+	}
 	files, err := filepath.Glob("./*.json")
 	for _, fname := range files {
 		err = os.Remove(fname)
@@ -123,6 +126,64 @@ func TestFetchWithEmptyFile(t *testing.T) {
 	}
 }
 
+func TestFetchReaderWithoutErrors(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	persister := &Persister{
+		logger:    logger,
+		directory: "./",
+	}
+	_ = ioutil.WriteFile("./test.json", []byte(testStr), 0644)
+	reader, transaction, err := persister.FetchReader()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+		return
+	}
+	data, _ := ioutil.ReadAll(reader)
+	_ = reader.Close()
+	if string(data) != testStr {
+		t.Error("Contents are not equal")
+	}
+	if transaction.ID() != "test" {
+		t.Errorf("Expected the name of the file as the ID, but received : %s", transaction.ID())
+	}
+	err = transaction.Commit()
+	if err != nil {
+		t.Errorf("Unexpected error occured : %v", err)
+	}
+	reader, _, err = persister.FetchReader()
+	if reader != nil || err != nil {
+		t.Errorf("Expected the store to be empty, but received the error : %v", err)
+	}
+}
+
+func TestFetchReaderWithEmptyFile(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	persister := &Persister{
+		logger:    logger,
+		directory: "./",
+	}
+	_ = ioutil.WriteFile("./test.json", []byte(""), 0644)
+	_, _, err = persister.FetchReader()
+	expectedErr := "file is empty, hence removed"
+	if err == nil {
+		t.Errorf("An error was not thrown, but expected : %s", expectedErr)
+		return
+	}
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error was not thrown, received error : %v", err)
+	}
+	files, err := filepath.Glob("./*.json")
+	for _, fname := range files {
+		err = os.Remove(fname)
+	}
+}
+
 func TestFetchWithInvalidDirectory(t *testing.T) {
 	logger, err := logging.NewLogger()
 	if err != nil {
@@ -184,6 +245,23 @@ func TestRollback(t *testing.T) {
 	}
 }
 
+func TestRollbackWithoutFile(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	persister := &Persister{
+		logger:    logger,
+		directory: "./wrong_dir",
+	}
+	// The publisher rolls back the transaction of an empty store
+	_, transaction, _ := persister.Fetch()
+	err = transaction.Rollback()
+	if err != nil {
+		t.Errorf("Unexpected error when rolling back without a file : %v", err)
+	}
+}
+
 func TestNewMethodWithoutDir(t *testing.T) {
 	config := &File{Path: "./testDir"}
 	logger, err := logging.NewLogger()
@@ -19,6 +19,7 @@
 package memory
 
 import (
+	"github.com/rs/xid"
 	"go.uber.org/zap"
 
 	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/store"
@@ -27,44 +28,61 @@ import (
 type (
 	Persister struct {
 		logger *zap.SugaredLogger
-		buffer chan string
+		buffer chan *Batch
+	}
+	// Batch is a batch kept in the buffer along with the ID assigned to it when it was written
+	Batch struct {
+		ID   string
+		Data string
 	}
 	Transaction struct {
-		Element string
-		Buffer  chan string
+		Element *Batch
+		Buffer  chan *Batch
 	}
 	Memory struct {
 	}
 )
 
+func (transaction *Transaction) ID() string {
+	if transaction.Element == nil {
+		return ""
+	}
+	return transaction.Element.ID
+}
+
 func (transaction *Transaction) Commit() error {
 	return nil
 }
 
 func (transaction *Transaction) Rollback() error {
-	if transaction.Buffer != nil {
+	if transaction.Buffer != nil && transaction.Element != nil {
 		transaction.Buffer <- transaction.Element
 	}
 	return nil
 }
 
 func (persister *Persister) Fetch() (string, store.Transaction, error) {
-	if len(persister.buffer) > 0 {
-		str := <-persister.buffer
-		transaction := &Transaction{Element: str}
-		return str, transaction, nil
-	} else {
+	select {
+	case batch := <-persister.buffer:
+		transaction := &Transaction{Element: batch}
+		return batch.Data, transaction, nil
+	default:
 		return "", &Transaction{}, nil
 	}
 }
 
+// Ordered returns true since the batches are fetched in the order they were written
+func (persister *Persister) Ordered() bool {
+	return true
+}
+
 func (persister *Persister) Write(str string) error {
-	persister.buffer <- str
+	persister.buffer <- &Batch{ID: xid.New().String(), Data: str}
 	return nil
 }
 
 func NewPersister(maxMetricsCount int, bufferSizeFactor int, logger *zap.SugaredLogger) (*Persister, error) {
-	inMemoryBuffer := make(chan string, maxMetricsCount*bufferSizeFactor)
+	inMemoryBuffer := make(chan *Batch, maxMetricsCount*bufferSizeFactor)
 	ps := &Persister{
 		logger: logger,
 		buffer: inMemoryBuffer,
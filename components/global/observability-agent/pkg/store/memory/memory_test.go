@@ -33,12 +33,12 @@ func TestFetchWithDataInBuffer(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building logger: %v", err)
 	}
-	buffer := make(chan string, 10)
+	buffer := make(chan *Batch, 10)
 	persister := &Persister{
 		logger: logger,
 		buffer: buffer,
 	}
-	buffer <- testStr
+	buffer <- &Batch{ID: "1", Data: testStr}
 	str, tx, err := persister.Fetch()
 	if len(buffer) != 0 {
 		t.Error("Buffer has not been cleaned")
@@ -51,6 +51,8 @@ func TestFetchWithDataInBuffer(t *testing.T) {
 	}
 	if tx == nil {
 		t.Error("Received an empty transaction struct")
+	} else if tx.ID() != "1" {
+		t.Errorf("Expected the ID of the batch, but received : %s", tx.ID())
 	}
 }
 
@@ -59,7 +61,7 @@ func TestFetchWithoutDataInBuffer(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building logger: %v", err)
 	}
-	buffer := make(chan string, 10)
+	buffer := make(chan *Batch, 10)
 	persister := &Persister{
 		logger: logger,
 		buffer: buffer,
@@ -78,7 +80,7 @@ func TestWrite(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error building logger: %v", err)
 	}
-	buffer := make(chan string, 10)
+	buffer := make(chan *Batch, 10)
 	persister := &Persister{
 		logger: logger,
 		buffer: buffer,
@@ -86,6 +88,8 @@ func TestWrite(t *testing.T) {
 	err = persister.Write(testStr)
 	if len(buffer) != 1 {
 		t.Error("String has not been written to the buffer.")
+	} else if batch := <-buffer; batch.ID == "" || batch.Data != testStr {
+		t.Errorf("Expected the batch with an ID, but received : %v", batch)
 	}
 	if err != nil {
 		t.Errorf("Unexpected error received : %v", err)
@@ -93,9 +97,9 @@ func TestWrite(t *testing.T) {
 }
 
 func TestRollback(t *testing.T) {
-	buffer := make(chan string, 10)
+	buffer := make(chan *Batch, 10)
 	transaction := Transaction{
-		Element: testStr,
+		Element: &Batch{ID: "1", Data: testStr},
 		Buffer:  buffer,
 	}
 	err := transaction.Rollback()
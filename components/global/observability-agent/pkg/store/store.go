@@ -18,12 +18,36 @@
 
 package store
 
+import (
+	"io"
+)
+
 type (
 	Persister interface {
 		Fetch() (string, Transaction, error)
 		Write(str string) error
 	}
+	// ReaderPersister is implemented by the persisters which are able to fetch a batch as a reader, so that the batch
+	// can be streamed without holding it in memory
+	ReaderPersister interface {
+		Persister
+		FetchReader() (BatchReader, Transaction, error)
+	}
+	// BatchReader reads the JSON array of a fetched batch, which can be read again after seeking to the start. It is
+	// closed before the transaction is committed or rolled back.
+	BatchReader interface {
+		io.ReadSeeker
+		io.Closer
+	}
+	// OrderedPersister is implemented by the persisters which return the batches in the order they were written
+	OrderedPersister interface {
+		Persister
+		Ordered() bool
+	}
 	Transaction interface {
+		// ID returns the ID assigned to the fetched batch when it was persisted, which stays the same when the batch
+		// is fetched again after a rollback
+		ID() string
 		Commit() error
 		Rollback() error
 	}
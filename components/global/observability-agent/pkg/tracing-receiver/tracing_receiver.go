@@ -34,28 +34,33 @@ import (
 	thriftzipkin "github.com/jaegertracing/jaeger/model/converter/thrift/zipkin"
 	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
 	"go.uber.org/zap"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
 )
 
 type (
 	TracingReceiver struct {
-		logger *zap.SugaredLogger
-		buffer chan string
-	}
-	ProcessedSpan struct {
-		TraceID     string `json:"traceId"`
-		ParentID    string `json:"parentId,omitempty"`
-		ID          string `json:"id"`
-		Name        string `json:"operationName"`
-		ServiceName string `json:"serviceName"`
-		SpanKind    string `json:"spanKind"`
-		Timestamp   int64  `json:"timestamp"`
-		Duration    int64  `json:"duration"`
-		Tags        string `json:"tags"`
+		logger        *zap.SugaredLogger
+		sanitizer     sanitizerzipkin.Sanitizer
+		buffer        chan string
+		forwardBuffer chan string
+		overflow      *overflow.Handler
+		redactor      *redaction.Redactor
 	}
 	ZipkinSpanHandler struct {
 		logger    *zap.SugaredLogger
 		sanitizer sanitizerzipkin.Sanitizer
 		receiver  *TracingReceiver
+		// rejected is set when a batch is rejected since the buffer is full
+		rejected bool
+	}
+	// rejectionResponseWriter writes 429 instead of the internal server error written by the Zipkin API handler when
+	// the spans were rejected since the buffer is full, so that the clients back off
+	rejectionResponseWriter struct {
+		http.ResponseWriter
+		spansHandler *ZipkinSpanHandler
 	}
 	// zapRecoveryWrapper wraps a zap logger into a gorilla RecoveryLogger
 	zapRecoveryWrapper struct {
@@ -72,26 +77,54 @@ func (wrapper zapRecoveryWrapper) Println(args ...interface{}) {
 	wrapper.logger.Error(fmt.Sprint(args...))
 }
 
-func New(logger *zap.SugaredLogger, buffer chan string) *TracingReceiver {
+// New creates a tracing receiver which writes the processed spans to the buffer, and the Zipkin v2 spans to the
+// forward buffer if it is not nil
+func New(logger *zap.SugaredLogger, buffer chan string, forwardBuffer chan string,
+	overflowHandler *overflow.Handler, redactor *redaction.Redactor) *TracingReceiver {
 	tracingReceiver := &TracingReceiver{
-		logger: logger,
-		buffer: buffer,
+		logger:        logger,
+		sanitizer:     sanitizerzipkin.NewChainedSanitizer(sanitizerzipkin.StandardSanitizers...),
+		buffer:        buffer,
+		forwardBuffer: forwardBuffer,
+		overflow:      overflowHandler,
+		redactor:      redactor,
 	}
 	return tracingReceiver
 }
 
 func (receiver *TracingReceiver) Run(errCh chan error) {
+	zWrapper := zapRecoveryWrapper{receiver.logger.Desugar()}
+	recoveryHandler := handlers.RecoveryHandler(handlers.RecoveryLogger(zWrapper), handlers.PrintRecoveryStack(true))
+	startZipkinHTTPAPI(receiver.logger, tracingReceiverPort, receiver, recoveryHandler, errCh)
+}
+
+// ServeHTTP serves the Zipkin API using a spans handler of its own for each request, so that the response of a
+// request whose spans were rejected can be told apart from the other internal server errors
+func (receiver *TracingReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	zipkinSpansHandler := &ZipkinSpanHandler{
 		logger:    receiver.logger,
-		sanitizer: sanitizerzipkin.NewChainedSanitizer(sanitizerzipkin.StandardSanitizers...),
+		sanitizer: receiver.sanitizer,
 		receiver:  receiver,
 	}
-	zWrapper := zapRecoveryWrapper{receiver.logger.Desugar()}
-	recoveryHandler := handlers.RecoveryHandler(handlers.RecoveryLogger(zWrapper), handlers.PrintRecoveryStack(true))
-	startZipkinHTTPAPI(receiver.logger, tracingReceiverPort, zipkinSpansHandler, recoveryHandler, errCh)
+	router := mux.NewRouter()
+	appzipkin.NewAPIHandler(zipkinSpansHandler).RegisterRoutes(router)
+	router.ServeHTTP(&rejectionResponseWriter{ResponseWriter: w, spansHandler: zipkinSpansHandler}, r)
+}
+
+// redactTags redacts the string tags of the span in place, so that both the processed and the forwarded spans are
+// redacted
+func (receiver *TracingReceiver) redactTags(span *model.Span) {
+	if receiver.redactor == nil {
+		return
+	}
+	for i, tag := range span.Tags {
+		if tag.VType == model.StringType {
+			span.Tags[i].VStr = receiver.redactor.Value(tag.Key, tag.VStr)
+		}
+	}
 }
 
-func (receiver *TracingReceiver) convertSpan(span *model.Span) ProcessedSpan {
+func (receiver *TracingReceiver) convertSpan(span *model.Span) tracing.ProcessedSpan {
 	spanKind := ""
 	processedTags := make(map[string]interface{})
 	tags := span.Tags
@@ -105,7 +138,7 @@ func (receiver *TracingReceiver) convertSpan(span *model.Span) ProcessedSpan {
 	if err != nil {
 		receiver.logger.Errorf("Error when marshalling the tags : %v", err)
 	}
-	processedSpan := ProcessedSpan{
+	processedSpan := tracing.ProcessedSpan{
 		TraceID:     span.TraceID.String(),
 		ID:          span.SpanID.String(),
 		Name:        span.OperationName,
@@ -143,20 +176,29 @@ func getTagValue(tag model.KeyValue) interface{} {
 func startZipkinHTTPAPI(
 	logger *zap.SugaredLogger,
 	zipkinPort int,
-	zipkinSpansHandler app.ZipkinSpansHandler,
+	handler http.Handler,
 	recoveryHandler func(http.Handler) http.Handler,
 	errCh chan error,
 ) {
-	zHandler := appzipkin.NewAPIHandler(zipkinSpansHandler)
-	r := mux.NewRouter()
-	zHandler.RegisterRoutes(r)
 	httpPortStr := ":" + strconv.Itoa(zipkinPort)
 	logger.Info("Listening for Zipkin HTTP traffic", zap.Int("zipkin.http-port", zipkinPort))
-	errCh <- http.ListenAndServe(httpPortStr, recoveryHandler(r))
+	errCh <- http.ListenAndServe(httpPortStr, recoveryHandler(handler))
+}
+
+func (writer *rejectionResponseWriter) WriteHeader(statusCode int) {
+	if statusCode == http.StatusInternalServerError && writer.spansHandler.rejected {
+		statusCode = http.StatusTooManyRequests
+	}
+	writer.ResponseWriter.WriteHeader(statusCode)
 }
 
+// SubmitZipkinBatch writes the spans of a batch to the buffer as a whole, so that a client retrying a rejected batch
+// does not duplicate any of its spans. Forwarding the spans is secondary, hence the spans which do not fit in the
+// forward buffer are only counted by the overflow handler.
 func (handler *ZipkinSpanHandler) SubmitZipkinBatch(spans []*zipkincore.Span, options app.SubmitBatchOptions) ([]*zipkincore.Response, error) {
 	responses := make([]*zipkincore.Response, len(spans))
+	var records []string
+	var forwardRecords []string
 	for _, span := range spans {
 		sanitized := handler.sanitizer.Sanitize(span)
 		processedSpans, err := thriftzipkin.ToDomainSpan(sanitized)
@@ -165,13 +207,32 @@ func (handler *ZipkinSpanHandler) SubmitZipkinBatch(spans []*zipkincore.Span, op
 		}
 
 		for _, span := range processedSpans {
+			handler.receiver.redactTags(span)
 			processedSpan := handler.receiver.convertSpan(span)
 			jsonStr, err := json.Marshal(processedSpan)
 			if err != nil {
 				return nil, fmt.Errorf("could not marshal span struct : %v", err)
 			}
-			handler.receiver.buffer <- string(jsonStr)
+			records = append(records, string(jsonStr))
 			handler.logger.Debugf("received span : %s", string(jsonStr))
+			if handler.receiver.forwardBuffer != nil {
+				jsonStr, err = json.Marshal(toZipkinSpan(span))
+				if err != nil {
+					return nil, fmt.Errorf("could not marshal the zipkin span : %v", err)
+				}
+				forwardRecords = append(forwardRecords, string(jsonStr))
+			}
+		}
+	}
+	err := handler.receiver.overflow.PutAll(handler.receiver.buffer, records)
+	if err != nil {
+		handler.rejected = err == overflow.ErrRejected
+		return nil, err
+	}
+	if handler.receiver.forwardBuffer != nil {
+		err = handler.receiver.overflow.PutAll(handler.receiver.forwardBuffer, forwardRecords)
+		if err != nil {
+			handler.logger.Debugf("Could not forward the spans : %v", err)
 		}
 	}
 	return responses, nil
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing_receiver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app"
+	sanitizerzipkin "github.com/jaegertracing/jaeger/cmd/collector/app/sanitizer/zipkin"
+	"github.com/jaegertracing/jaeger/thrift-gen/zipkincore"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/logging"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/overflow"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/redaction"
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
+)
+
+func TestSubmitZipkinBatchWithFullBuffer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	overflowHandler, err := overflow.New(&overflow.Overflow{Policy: overflow.RejectPolicy}, logger)
+	if err != nil {
+		t.Fatalf("Could not create the overflow handler : %v", err)
+	}
+	buffer := make(chan string, 1)
+	handler := &ZipkinSpanHandler{
+		logger:    logger,
+		sanitizer: sanitizerzipkin.NewChainedSanitizer(sanitizerzipkin.StandardSanitizers...),
+		receiver:  New(logger, buffer, nil, overflowHandler, nil),
+	}
+	spans := []*zipkincore.Span{
+		{TraceID: 1, ID: 1, Name: "get /pets"},
+		{TraceID: 1, ID: 2, Name: "get /owners"},
+	}
+	_, err = handler.SubmitZipkinBatch(spans, app.SubmitBatchOptions{InboundTransport: app.HTTPTransport})
+	if err != overflow.ErrRejected || !handler.rejected {
+		t.Errorf("Expected the error %v, but received %v", overflow.ErrRejected, err)
+	}
+	// The buffer has space for only one of the spans, hence the batch should be rejected as a whole
+	if len(buffer) != 0 {
+		t.Errorf("Expected no spans in the buffer, but received %d", len(buffer))
+	}
+}
+
+func TestSubmitZipkinBatchWithRedaction(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	redactor, err := redaction.New(&redaction.Redaction{
+		Rules: []*redaction.Rule{{Keys: []string{"http.url"}, QueryParameters: []string{"token"}}},
+	})
+	if err != nil {
+		t.Fatalf("Could not create the redactor : %v", err)
+	}
+	buffer := make(chan string, 1)
+	forwardBuffer := make(chan string, 1)
+	handler := &ZipkinSpanHandler{
+		logger:    logger,
+		sanitizer: sanitizerzipkin.NewChainedSanitizer(sanitizerzipkin.StandardSanitizers...),
+		receiver:  New(logger, buffer, forwardBuffer, nil, redactor),
+	}
+	spans := []*zipkincore.Span{
+		{
+			TraceID: 1,
+			ID:      1,
+			Name:    "get /pets",
+			BinaryAnnotations: []*zipkincore.BinaryAnnotation{
+				{
+					Key:            "http.url",
+					Value:          []byte("/pets?token=abc"),
+					AnnotationType: zipkincore.AnnotationType_STRING,
+				},
+			},
+		},
+	}
+	_, err = handler.SubmitZipkinBatch(spans, app.SubmitBatchOptions{InboundTransport: app.HTTPTransport})
+	if err != nil {
+		t.Fatalf("Spans could not be submitted : %v", err)
+	}
+	processedSpan := tracing.ProcessedSpan{}
+	err = json.Unmarshal([]byte(<-buffer), &processedSpan)
+	if err != nil {
+		t.Fatalf("Could not unmarshal the processed span : %v", err)
+	}
+	expectedTags := `{"http.url":"/pets?token=[REDACTED]"}`
+	if processedSpan.Tags != expectedTags {
+		t.Errorf("Expected the tags %s, but received %s", expectedTags, processedSpan.Tags)
+	}
+	zipkinSpan := tracing.ZipkinSpan{}
+	err = json.Unmarshal([]byte(<-forwardBuffer), &zipkinSpan)
+	if err != nil {
+		t.Fatalf("Could not unmarshal the forwarded span : %v", err)
+	}
+	if zipkinSpan.Tags["http.url"] != "/pets?token=[REDACTED]" {
+		t.Errorf("Expected the forwarded tag to be redacted, but received %s", zipkinSpan.Tags["http.url"])
+	}
+}
+
+func TestServeHTTPWithFullBuffer(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	overflowHandler, err := overflow.New(&overflow.Overflow{Policy: overflow.RejectPolicy}, logger)
+	if err != nil {
+		t.Fatalf("Could not create the overflow handler : %v", err)
+	}
+	buffer := make(chan string, 2)
+	receiver := New(logger, buffer, nil, overflowHandler, nil)
+	tests := []struct {
+		spans          string
+		expectedStatus int
+		expectedSpans  int
+	}{
+		{
+			spans:          `[{"traceId":"0000000000000001","id":"0000000000000001","name":"get /pets"}]`,
+			expectedStatus: http.StatusAccepted,
+			expectedSpans:  1,
+		},
+		{
+			spans: `[{"traceId":"0000000000000001","id":"0000000000000002","name":"get /owners"},` +
+				`{"traceId":"0000000000000001","id":"0000000000000003","name":"get /vets"}]`,
+			expectedStatus: http.StatusTooManyRequests,
+			expectedSpans:  1,
+		},
+		{
+			spans:          `[{"traceId":"0000000000000001","id":"0000000000000002"`,
+			expectedStatus: http.StatusBadRequest,
+			expectedSpans:  1,
+		},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/spans", strings.NewReader(test.spans))
+		req.Header.Set("Content-Type", "application/json")
+		recorder := httptest.NewRecorder()
+		receiver.ServeHTTP(recorder, req)
+		if recorder.Code != test.expectedStatus {
+			t.Errorf("Expected the status %d for the spans %s, but received %d", test.expectedStatus, test.spans,
+				recorder.Code)
+		}
+		if len(buffer) != test.expectedSpans {
+			t.Errorf("Expected %d spans in the buffer, but received %d", test.expectedSpans, len(buffer))
+		}
+	}
+}
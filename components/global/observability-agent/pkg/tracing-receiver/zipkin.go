@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing_receiver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jaegertracing/jaeger/model"
+
+	"github.com/cellery-io/mesh-observability/components/global/observability-agent/pkg/tracing"
+)
+
+// toZipkinSpan converts a span back to the Zipkin v2 format keeping the microsecond precision and every tag
+func toZipkinSpan(span *model.Span) tracing.ZipkinSpan {
+	zipkinSpan := tracing.ZipkinSpan{
+		TraceID:   formatTraceID(span.TraceID),
+		ID:        fmt.Sprintf("%016x", uint64(span.SpanID)),
+		Name:      span.OperationName,
+		Timestamp: span.StartTime.UnixNano() / 1000,
+		Duration:  span.Duration.Nanoseconds() / 1000,
+		Debug:     span.Flags.IsDebug(),
+	}
+	if parentID := span.ParentSpanID(); parentID != 0 {
+		zipkinSpan.ParentID = fmt.Sprintf("%016x", uint64(parentID))
+	}
+	if span.Process != nil {
+		zipkinSpan.LocalEndpoint = &tracing.ZipkinEndpoint{
+			ServiceName: span.Process.ServiceName,
+		}
+	}
+	for _, tag := range span.Tags {
+		if tag.Key == "span.kind" {
+			zipkinSpan.Kind = strings.ToUpper(tag.AsString())
+			continue
+		}
+		if zipkinSpan.Tags == nil {
+			zipkinSpan.Tags = make(map[string]string)
+		}
+		zipkinSpan.Tags[tag.Key] = tag.AsString()
+	}
+	for _, log := range span.Logs {
+		zipkinSpan.Annotations = append(zipkinSpan.Annotations, tracing.ZipkinAnnotation{
+			Timestamp: log.Timestamp.UnixNano() / 1000,
+			Value:     annotationValue(log.Fields),
+		})
+	}
+	return zipkinSpan
+}
+
+// annotationValue returns the event of a log, or all the fields of the log when it does not have an event
+func annotationValue(fields []model.KeyValue) string {
+	if len(fields) == 1 && fields[0].Key == "event" {
+		return fields[0].AsString()
+	}
+	values := make(map[string]string)
+	for _, field := range fields {
+		values[field.Key] = field.AsString()
+	}
+	bytesArr, _ := json.Marshal(values)
+	return string(bytesArr)
+}
+
+// formatTraceID formats the trace id with the 16 or 32 hex characters expected by Zipkin
+func formatTraceID(traceID model.TraceID) string {
+	if traceID.High == 0 {
+		return fmt.Sprintf("%016x", traceID.Low)
+	}
+	return fmt.Sprintf("%016x%016x", traceID.High, traceID.Low)
+}
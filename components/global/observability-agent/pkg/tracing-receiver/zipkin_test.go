@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing_receiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/model"
+)
+
+func TestToZipkinSpan(t *testing.T) {
+	span := &model.Span{
+		TraceID:       model.NewTraceID(0, 0x1bbbe537),
+		SpanID:        model.NewSpanID(2),
+		OperationName: "get /pets",
+		References:    []model.SpanRef{model.NewChildOfRef(model.NewTraceID(0, 0x1bbbe537), model.NewSpanID(1))},
+		StartTime:     time.Unix(1559000000, 123000),
+		Duration:      20456 * time.Microsecond,
+		Tags:          model.KeyValues{model.String("span.kind", "server"), model.Int64("http.status_code", 200)},
+		Logs: []model.Log{
+			{Timestamp: time.Unix(1559000000, 200000), Fields: []model.KeyValue{model.String("event", "retry")}},
+			{Timestamp: time.Unix(1559000000, 300000), Fields: []model.KeyValue{model.Int64("attempt", 2)}},
+		},
+		Process: model.NewProcess("pet-be", nil),
+	}
+	zipkinSpan := toZipkinSpan(span)
+	if zipkinSpan.TraceID != "000000001bbbe537" || zipkinSpan.ID != "0000000000000002" ||
+		zipkinSpan.ParentID != "0000000000000001" {
+		t.Errorf("Unexpected ids : %s, %s, %s", zipkinSpan.TraceID, zipkinSpan.ID, zipkinSpan.ParentID)
+	}
+	if zipkinSpan.Timestamp != 1559000000000123 || zipkinSpan.Duration != 20456 {
+		t.Errorf("Unexpected timestamp or duration : %d, %d", zipkinSpan.Timestamp, zipkinSpan.Duration)
+	}
+	if zipkinSpan.Kind != "SERVER" || zipkinSpan.LocalEndpoint.ServiceName != "pet-be" {
+		t.Errorf("Unexpected kind or service : %s, %s", zipkinSpan.Kind, zipkinSpan.LocalEndpoint.ServiceName)
+	}
+	if len(zipkinSpan.Tags) != 1 || zipkinSpan.Tags["http.status_code"] != "200" {
+		t.Errorf("Unexpected tags : %v", zipkinSpan.Tags)
+	}
+	if len(zipkinSpan.Annotations) != 2 || zipkinSpan.Annotations[0].Value != "retry" ||
+		zipkinSpan.Annotations[1].Value != "{\"attempt\":\"2\"}" {
+		t.Errorf("Unexpected annotations : %v", zipkinSpan.Annotations)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2019, WSO2 Inc. (http://www.wso2.org) All Rights Reserved.
+ *
+ * WSO2 Inc. licenses this file to you under the Apache License,
+ * Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+package tracing
+
+type (
+	// ProcessedSpan is the record written to the buffer of the tracing pipeline by the tracing receiver, and read by
+	// the codecs and the exporters of the pipeline
+	ProcessedSpan struct {
+		TraceID     string `json:"traceId"`
+		ParentID    string `json:"parentId,omitempty"`
+		ID          string `json:"id"`
+		Name        string `json:"operationName"`
+		ServiceName string `json:"serviceName"`
+		SpanKind    string `json:"spanKind"`
+		Timestamp   int64  `json:"timestamp"`
+		Duration    int64  `json:"duration"`
+		Tags        string `json:"tags"`
+	}
+
+	// ZipkinSpan is a span in the Zipkin v2 JSON format. The received spans are kept in this format when they are
+	// forwarded to a tracing backend, since both Zipkin and Jaeger are able to read it without losing any detail.
+	ZipkinSpan struct {
+		TraceID       string             `json:"traceId"`
+		ParentID      string             `json:"parentId,omitempty"`
+		ID            string             `json:"id"`
+		Kind          string             `json:"kind,omitempty"`
+		Name          string             `json:"name,omitempty"`
+		Timestamp     int64              `json:"timestamp,omitempty"`
+		Duration      int64              `json:"duration,omitempty"`
+		Debug         bool               `json:"debug,omitempty"`
+		LocalEndpoint *ZipkinEndpoint    `json:"localEndpoint,omitempty"`
+		Annotations   []ZipkinAnnotation `json:"annotations,omitempty"`
+		Tags          map[string]string  `json:"tags,omitempty"`
+	}
+	ZipkinEndpoint struct {
+		ServiceName string `json:"serviceName,omitempty"`
+	}
+	ZipkinAnnotation struct {
+		Timestamp int64  `json:"timestamp"`
+		Value     string `json:"value"`
+	}
+)
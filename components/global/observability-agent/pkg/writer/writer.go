@@ -36,6 +36,7 @@ type (
 		Buffer          chan string
 		LastWrittenTime time.Time
 		Persister       store.Persister
+		Notify          chan<- struct{}
 	}
 )
 
@@ -67,9 +68,21 @@ func (writer *Writer) write() error {
 		writer.restore(elements)
 		return err
 	}
+	writer.notify()
 	return nil
 }
 
+// notify lets the publisher know that a new batch was persisted, without waiting if a signal is already pending
+func (writer *Writer) notify() {
+	if writer.Notify == nil {
+		return
+	}
+	select {
+	case writer.Notify <- struct{}{}:
+	default:
+	}
+}
+
 func (writer *Writer) flushBuffer() {
 	for {
 		if len(writer.Buffer) == 0 {
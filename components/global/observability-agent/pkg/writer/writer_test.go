@@ -43,6 +43,10 @@ type (
 	MockTransaction  struct{}
 )
 
+func (mockTransaction *MockTransaction) ID() string {
+	return ""
+}
+
 func (mockTransaction *MockTransaction) Commit() error {
 	return nil
 }
@@ -259,3 +263,38 @@ func TestFlushBuffer(t *testing.T) {
 			len(mockPersister.actions))
 	}
 }
+
+func TestWriteNotifiesPublisher(t *testing.T) {
+	logger, err := logging.NewLogger()
+	if err != nil {
+		t.Errorf("Error building logger: %v", err)
+	}
+	buffer := make(chan string, 10)
+	notifyCh := make(chan struct{}, 1)
+	writer := &Writer{
+		WaitingTimeSec:  2,
+		WaitingSize:     1,
+		Logger:          logger,
+		Buffer:          buffer,
+		LastWrittenTime: time.Now(),
+		Persister:       &MockPersister{expectedStr: fmt.Sprintf("[%s]", testStr)},
+		Notify:          notifyCh,
+	}
+	buffer <- testStr
+	buffer <- testStr
+	writer.flushBuffer()
+	if len(notifyCh) != 1 {
+		t.Error("Publisher was not notified about the written batches")
+	}
+
+	writer.Persister = &MockPersisterErr{}
+	<-notifyCh
+	buffer <- testStr
+	err = writer.write()
+	if err == nil {
+		t.Error("An error was not thrown, but expected : test error 1")
+	}
+	if len(notifyCh) != 0 {
+		t.Error("Publisher was notified even though the batch was not written")
+	}
+}
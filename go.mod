@@ -11,17 +11,26 @@ replace (
 
 require (
 	github.com/DATA-DOG/go-sqlmock v1.3.3
-	github.com/apache/thrift v0.13.0 // indirect
+	github.com/Shopify/sarama v1.24.1
+	github.com/apache/thrift v0.13.0
+	github.com/envoyproxy/go-control-plane v0.9.0
 	github.com/go-openapi/runtime v0.19.8 // indirect
 	github.com/go-openapi/spec v0.19.4 // indirect
 	github.com/go-openapi/validate v0.19.5 // indirect
 	github.com/go-sql-driver/mysql v1.4.1
 	github.com/gofrs/flock v0.7.1
+	github.com/gogo/googleapis v1.1.0
 	github.com/gogo/protobuf v1.2.1
+	github.com/golang/protobuf v1.3.2
+	github.com/golang/snappy v0.0.1
 	github.com/gorilla/handlers v1.4.2
 	github.com/gorilla/mux v1.7.3
 	github.com/grpc-ecosystem/grpc-gateway v1.12.1 // indirect
 	github.com/jaegertracing/jaeger v0.0.0-00010101000000-000000000000
+	github.com/klauspost/compress v1.10.3
+	github.com/prometheus/client_model v0.0.0-20190812154241-14fe0d1b01d4
+	github.com/prometheus/common v0.2.0
+	github.com/prometheus/prometheus v2.5.0+incompatible
 	github.com/rs/cors v1.7.0
 	github.com/rs/xid v1.2.1
 	github.com/uber/tchannel-go v1.16.0 // indirect